@@ -0,0 +1,144 @@
+// Package bench provides canonical workloads for measuring the
+// performance of lis, and a runner that reports the measurements in a
+// machine-readable form.
+//
+// Workloads are generated deterministically from a seed, so that
+// results from different machines or different revisions of lis can
+// be compared meaningfully. The runner also measures the traces in
+// the corpus package, which are shaped more like real inputs.
+package bench
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/danderson/go-lnds/corpus"
+	"github.com/danderson/go-lnds/lis"
+)
+
+// A Workload is a named family of inputs.
+type Workload struct {
+	// Name is a short identifier for the workload, suitable for use
+	// as a benchmark name.
+	Name string
+	// Gen returns an input of length n, drawing any randomness it
+	// needs from r.
+	Gen func(n int, r *rand.Rand) []int
+}
+
+// Workloads is the canonical set of workloads.
+var Workloads = []Workload{
+	{"sorted", sorted},
+	{"reversed", reversed},
+	{"random", random},
+	{"sawtooth", sawtooth},
+	{"duplicates", duplicates},
+}
+
+func sorted(n int, r *rand.Rand) []int {
+	ret := make([]int, n)
+	for i := range ret {
+		ret[i] = i
+	}
+	return ret
+}
+
+func reversed(n int, r *rand.Rand) []int {
+	ret := make([]int, n)
+	for i := range ret {
+		ret[i] = n - i
+	}
+	return ret
+}
+
+func random(n int, r *rand.Rand) []int {
+	ret := make([]int, n)
+	for i := range ret {
+		ret[i] = r.IntN(n + 1)
+	}
+	return ret
+}
+
+// sawtooth is sorted runs of length 100, each starting over from 0.
+func sawtooth(n int, r *rand.Rand) []int {
+	const tooth = 100
+	ret := make([]int, n)
+	for i := range ret {
+		ret[i] = i % tooth
+	}
+	return ret
+}
+
+// duplicates is random values from a small alphabet, so that most
+// elements compare equal to many others.
+func duplicates(n int, r *rand.Rand) []int {
+	const alphabet = 8
+	ret := make([]int, n)
+	for i := range ret {
+		ret[i] = r.IntN(alphabet)
+	}
+	return ret
+}
+
+// Result is the measured performance of one workload at one input
+// size.
+type Result struct {
+	Workload    string `json:"workload"`
+	N           int    `json:"n"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	AllocsPerOp int64  `json:"allocs_per_op"`
+	BytesPerOp  int64  `json:"bytes_per_op"`
+}
+
+// Run benchmarks lis.LIS on every workload in Workloads, at every
+// input size in sizes, and then on every trace in the embedded
+// corpus, at the trace's own size. Inputs are generated from seed.
+//
+// Results for corpus traces have a Workload of "trace/" followed by
+// the trace name. Run returns an error if the corpus fails to load.
+func Run(sizes []int, seed uint64) ([]Result, error) {
+	var ret []Result
+	for _, w := range Workloads {
+		for _, n := range sizes {
+			input := w.Gen(n, rand.New(rand.NewPCG(seed, uint64(n))))
+			ret = append(ret, measure(w.Name, input))
+		}
+	}
+
+	traces, err := corpus.All()
+	if err != nil {
+		return nil, fmt.Errorf("loading corpus: %w", err)
+	}
+	for _, t := range traces {
+		ret = append(ret, measure("trace/"+t.Name, t.Values))
+	}
+	return ret, nil
+}
+
+// measure benchmarks lis.LIS on input.
+func measure(workload string, input []int) Result {
+	res := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			lis.LIS(input, cmp.Compare)
+		}
+	})
+	return Result{
+		Workload:    workload,
+		N:           len(input),
+		NsPerOp:     res.NsPerOp(),
+		AllocsPerOp: res.AllocsPerOp(),
+		BytesPerOp:  res.AllocedBytesPerOp(),
+	}
+}
+
+// WriteJSON writes results to w as a JSON array.
+func WriteJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
@@ -0,0 +1,118 @@
+package bench
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/danderson/go-lnds/corpus"
+	"github.com/danderson/go-lnds/lis"
+)
+
+func TestWorkloads(t *testing.T) {
+	t.Parallel()
+
+	for _, w := range Workloads {
+		t.Run(w.Name, func(t *testing.T) {
+			for _, n := range []int{0, 1, 10, 1000} {
+				a := w.Gen(n, rand.New(rand.NewPCG(1, 2)))
+				b := w.Gen(n, rand.New(rand.NewPCG(1, 2)))
+				if len(a) != n {
+					t.Errorf("len(Gen(%d)) = %d, want %d", n, len(a), n)
+				}
+				if !slices.Equal(a, b) {
+					t.Errorf("Gen(%d) is not deterministic for a given seed", n)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	want := []Result{{Workload: "sorted", N: 10, NsPerOp: 1, AllocsPerOp: 2, BytesPerOp: 3}}
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	var got []Result
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("WriteJSON round trip = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkLIS(b *testing.B) {
+	for _, w := range Workloads {
+		for _, n := range []int{100, 10_000, 1_000_000} {
+			input := w.Gen(n, rand.New(rand.NewPCG(1, uint64(n))))
+			b.Run(fmt.Sprintf("%s/%d", w.Name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					lis.LIS(input, cmp.Compare)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkTraces(b *testing.B) {
+	traces, err := corpus.All()
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, t := range traces {
+		b.Run(t.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				lis.LIS(t.Values, cmp.Compare)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	// Run uses testing.Benchmark, which runs each benchmark for
+	// -test.benchtime. Make that a single iteration, so this test
+	// checks that Run works without taking seconds per workload.
+	bt := flag.Lookup("test.benchtime")
+	old := bt.Value.String()
+	if err := bt.Value.Set("1x"); err != nil {
+		t.Fatal(err)
+	}
+	defer bt.Value.Set(old)
+
+	sizes := []int{10, 100}
+	got, err := Run(sizes, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want []string
+	for _, w := range Workloads {
+		for range sizes {
+			want = append(want, w.Name)
+		}
+	}
+	for _, name := range corpus.Names() {
+		want = append(want, "trace/"+name)
+	}
+	var gotNames []string
+	for _, r := range got {
+		gotNames = append(gotNames, r.Workload)
+		if r.N <= 0 {
+			t.Errorf("Run result for %s has N = %d, want positive", r.Workload, r.N)
+		}
+	}
+	if !slices.Equal(gotNames, want) {
+		t.Errorf("Run workloads = %v, want %v", gotNames, want)
+	}
+}
@@ -0,0 +1,72 @@
+// Package cmpx provides combinators for building comparators, such as
+// the ones taken by the lis package.
+//
+// A comparator is a func(a, b T) int that returns a negative number
+// if a sorts before b, a positive number if a sorts after b, and zero
+// if they are equal, like cmp.Compare.
+package cmpx
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Asc returns a comparator that orders values by ascending key.
+func Asc[T any, K cmp.Ordered](key func(T) K) func(T, T) int {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// Desc returns a comparator that orders values by descending key.
+func Desc[T any, K cmp.Ordered](key func(T) K) func(T, T) int {
+	return func(a, b T) int {
+		return cmp.Compare(key(b), key(a))
+	}
+}
+
+// Reverse returns a comparator that orders values in the opposite
+// order to cmp.
+func Reverse[T any](cmp func(T, T) int) func(T, T) int {
+	return func(a, b T) int {
+		return cmp(b, a)
+	}
+}
+
+// Chain returns a comparator that orders values by the first of cmps
+// that doesn't consider them equal. Values are equal if all of cmps
+// consider them equal.
+//
+// Chain is typically used with Asc and Desc to build multi-key
+// orderings, where each key can be ascending or descending:
+//
+//	byNameThenNewest := cmpx.Chain(
+//		cmpx.Asc(func(f File) string { return f.Name }),
+//		cmpx.Desc(func(f File) int64 { return f.ModTime.Unix() }),
+//	)
+func Chain[T any](cmps ...func(T, T) int) func(T, T) int {
+	return func(a, b T) int {
+		for _, cmp := range cmps {
+			if c := cmp(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// Lexicographic returns a comparator that orders slices
+// lexicographically, comparing elements with cmp. A slice that is a
+// prefix of another sorts before it.
+//
+// This is useful for ordering tokenized paths, version tuples and
+// similar values. The slice type can't be inferred from cmp, so it
+// comes first, and the element type is inferred:
+//
+//	type version []int
+//	byVersion := cmpx.Lexicographic[version](cmp.Compare[int])
+func Lexicographic[S ~[]E, E any](cmp func(E, E) int) func(S, S) int {
+	return func(a, b S) int {
+		return slices.CompareFunc(a, b, cmp)
+	}
+}
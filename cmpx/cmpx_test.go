@@ -0,0 +1,117 @@
+package cmpx
+
+import (
+	stdcmp "cmp"
+	"slices"
+	"testing"
+
+	"github.com/danderson/go-lnds/lis"
+	"github.com/google/go-cmp/cmp"
+)
+
+type row struct {
+	Group string
+	Score int
+}
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	in := []row{
+		{"b", 1},
+		{"a", 1},
+		{"a", 3},
+		{"b", 2},
+		{"a", 2},
+	}
+	want := []row{
+		{"a", 3},
+		{"a", 2},
+		{"a", 1},
+		{"b", 2},
+		{"b", 1},
+	}
+
+	byGroupThenScoreDesc := Chain(
+		Asc(func(r row) string { return r.Group }),
+		Desc(func(r row) int { return r.Score }),
+	)
+	got := slices.Clone(in)
+	slices.SortFunc(got, byGroupThenScoreDesc)
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("sorted with Chain is wrong (-got+want):\n%s", diff)
+	}
+
+	reversed := slices.Clone(in)
+	slices.SortFunc(reversed, Reverse(byGroupThenScoreDesc))
+	slices.Reverse(want)
+	if diff := cmp.Diff(reversed, want); diff != "" {
+		t.Errorf("sorted with Reverse(Chain) is wrong (-got+want):\n%s", diff)
+	}
+
+	if got := Chain[row]()(in[0], in[1]); got != 0 {
+		t.Errorf("empty Chain returned %d, want 0", got)
+	}
+}
+
+func TestChainWithLIS(t *testing.T) {
+	t.Parallel()
+
+	in := []row{
+		{"a", 3},
+		{"b", 9},
+		{"a", 1},
+		{"b", 2},
+		{"b", 1},
+	}
+	gotSorted, gotRest := lis.LIS(in, Chain(
+		Asc(func(r row) string { return r.Group }),
+		Desc(func(r row) int { return r.Score }),
+	))
+	if diff := cmp.Diff(gotSorted, []row{{"a", 3}, {"a", 1}, {"b", 2}, {"b", 1}}); diff != "" {
+		t.Errorf("LIS subsequence is wrong (-got+want):\n%s", diff)
+	}
+	if diff := cmp.Diff(gotRest, []row{{"b", 9}}); diff != "" {
+		t.Errorf("LIS remainder is wrong (-got+want):\n%s", diff)
+	}
+}
+
+func TestLexicographic(t *testing.T) {
+	t.Parallel()
+
+	type version []int
+	versions := []version{
+		{1, 10},
+		{1, 2, 3},
+		{1, 2},
+		{},
+		{2},
+		{1, 10},
+	}
+	want := []version{
+		{},
+		{1, 2},
+		{1, 2, 3},
+		{1, 10},
+		{1, 10},
+		{2},
+	}
+
+	byVersion := Lexicographic[version](stdcmp.Compare[int])
+	got := slices.Clone(versions)
+	slices.SortFunc(got, byVersion)
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("sorted with Lexicographic is wrong (-got+want):\n%s", diff)
+	}
+	if got := byVersion(versions[0], versions[0]); got != 0 {
+		t.Errorf("comparing a slice to itself = %d, want 0", got)
+	}
+	if got := byVersion(versions[0][:1], versions[0]); got != -1 {
+		t.Errorf("comparing a slice to its extension = %d, want -1", got)
+	}
+
+	byInts := Lexicographic[[]int](stdcmp.Compare[int])
+	if got := byInts([]int{1, 2}, []int{1, 3}); got != -1 {
+		t.Errorf("comparing [1 2] to [1 3] = %d, want -1", got)
+	}
+}
@@ -0,0 +1,67 @@
+package cmpx
+
+// A Comparison is one recorded call to a traced comparator.
+type Comparison[T any] struct {
+	A, B   T
+	Result int
+}
+
+// A Trace is a record of the most recent comparisons made by a
+// comparator returned by Traced. It remembers a bounded number of
+// comparisons, discarding the oldest ones first.
+//
+// A Trace is not safe for concurrent use.
+type Trace[T any] struct {
+	ring  []Comparison[T]
+	next  int // index in ring for the next comparison
+	total int
+}
+
+// NewTrace returns a Trace that remembers the most recent size
+// comparisons.
+func NewTrace[T any](size int) *Trace[T] {
+	return &Trace[T]{ring: make([]Comparison[T], 0, max(size, 0))}
+}
+
+func (t *Trace[T]) record(c Comparison[T]) {
+	t.total++
+	if cap(t.ring) == 0 {
+		return
+	}
+	if len(t.ring) < cap(t.ring) {
+		t.ring = append(t.ring, c)
+	} else {
+		t.ring[t.next] = c
+	}
+	t.next = (t.next + 1) % cap(t.ring)
+}
+
+// Comparisons returns the remembered comparisons, oldest first.
+func (t *Trace[T]) Comparisons() []Comparison[T] {
+	ret := make([]Comparison[T], 0, len(t.ring))
+	if len(t.ring) < cap(t.ring) {
+		return append(ret, t.ring...)
+	}
+	ret = append(ret, t.ring[t.next:]...)
+	return append(ret, t.ring[:t.next]...)
+}
+
+// Total returns the number of comparisons made since the Trace was
+// created, including those that are no longer remembered.
+func (t *Trace[T]) Total() int {
+	return t.total
+}
+
+// Traced returns a comparator that orders values like cmp, and
+// records every comparison it makes in trace.
+//
+// Traced is a debugging aid: passing a traced comparator to a
+// function like lis.LIS shows exactly which comparisons led to its
+// result.
+func Traced[T any](cmp func(T, T) int, trace *Trace[T]) func(T, T) int {
+	return func(a, b T) int {
+		ret := cmp(a, b)
+		trace.record(Comparison[T]{a, b, ret})
+		return ret
+	}
+}
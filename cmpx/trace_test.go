@@ -0,0 +1,57 @@
+package cmpx
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/danderson/go-lnds/lis"
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestTraced(t *testing.T) {
+	t.Parallel()
+
+	trace := NewTrace[int](3)
+	c := Traced(cmp.Compare[int], trace)
+	c(1, 2)
+	c(2, 2)
+	if diff := diff.Diff(trace.Comparisons(), []Comparison[int]{{1, 2, -1}, {2, 2, 0}}); diff != "" {
+		t.Errorf("Comparisons() is wrong (-got+want):\n%s", diff)
+	}
+
+	c(3, 2)
+	c(4, 2)
+	if diff := diff.Diff(trace.Comparisons(), []Comparison[int]{{2, 2, 0}, {3, 2, 1}, {4, 2, 1}}); diff != "" {
+		t.Errorf("Comparisons() after wraparound is wrong (-got+want):\n%s", diff)
+	}
+	if got, want := trace.Total(), 4; got != want {
+		t.Errorf("Total() = %d, want %d", got, want)
+	}
+}
+
+func TestTracedZeroSize(t *testing.T) {
+	t.Parallel()
+
+	trace := NewTrace[int](0)
+	c := Traced(cmp.Compare[int], trace)
+	c(1, 2)
+	if got := trace.Comparisons(); len(got) != 0 {
+		t.Errorf("Comparisons() = %v, want none", got)
+	}
+	if got, want := trace.Total(), 1; got != want {
+		t.Errorf("Total() = %d, want %d", got, want)
+	}
+}
+
+func TestTracedWithLIS(t *testing.T) {
+	t.Parallel()
+
+	// A sorted input only takes the fast path, one comparison per
+	// element after the first.
+	trace := NewTrace[int](10)
+	lis.LIS([]int{1, 2, 3, 4}, Traced(cmp.Compare[int], trace))
+	want := []Comparison[int]{{2, 1, 1}, {3, 2, 1}, {4, 3, 1}}
+	if diff := diff.Diff(trace.Comparisons(), want); diff != "" {
+		t.Errorf("LIS comparisons are wrong (-got+want):\n%s", diff)
+	}
+}
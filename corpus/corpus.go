@@ -0,0 +1,105 @@
+// Package corpus provides a small embedded corpus of ordering traces,
+// for benchmarking and tuning lis on data shaped like real workloads
+// rather than on uniformly random inputs.
+//
+// The traces are synthetic. Each one is generated by a simple model
+// of a real-world source of disorder, described in its Description,
+// and contains no real data. The models are deterministic, and the
+// embedded files are regenerated with:
+//
+//	go test ./corpus -update
+//
+// The corpus does not yet include anonymized real-world traces. None
+// have been sourced with the provenance needed to ship them, so the
+// models stand in for them.
+package corpus
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed traces/*.txt
+var traces embed.FS
+
+// A Trace is a sequence of values that should ideally be in
+// non-decreasing order, but isn't quite.
+type Trace struct {
+	// Name identifies the trace.
+	Name string
+	// Description explains what the trace models.
+	Description string
+	// Values are the trace's values, in the order they were
+	// observed. Values always fit in 32 bits, so that traces load on
+	// every platform: timestamps, for example, are stored as offsets
+	// from the start of the trace.
+	Values []int
+}
+
+// Names returns the names of all the traces in the corpus, in sorted
+// order.
+func Names() []string {
+	ents, err := traces.ReadDir("traces")
+	if err != nil {
+		panic(fmt.Sprintf("reading embedded traces: %v", err))
+	}
+	var ret []string
+	for _, ent := range ents {
+		ret = append(ret, strings.TrimSuffix(ent.Name(), ".txt"))
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+// Load returns the named trace.
+func Load(name string) (Trace, error) {
+	bs, err := traces.ReadFile(path.Join("traces", name+".txt"))
+	if err != nil {
+		return Trace{}, fmt.Errorf("unknown trace %q", name)
+	}
+	return parse(name, bs)
+}
+
+// All returns every trace in the corpus, sorted by name.
+func All() ([]Trace, error) {
+	var ret []Trace
+	for _, name := range Names() {
+		t, err := Load(name)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, t)
+	}
+	return ret, nil
+}
+
+// parse parses a trace file. Lines starting with "#" are the
+// description, and every other line is one integer value.
+func parse(name string, bs []byte) (Trace, error) {
+	ret := Trace{Name: name}
+	var desc []string
+	s := bufio.NewScanner(bytes.NewReader(bs))
+	for lineNum := 1; s.Scan(); lineNum++ {
+		line := s.Text()
+		if rest, ok := strings.CutPrefix(line, "#"); ok {
+			desc = append(desc, strings.TrimSpace(rest))
+			continue
+		}
+		v, err := strconv.Atoi(line)
+		if err != nil {
+			return Trace{}, fmt.Errorf("trace %q line %d: %w", name, lineNum, err)
+		}
+		ret.Values = append(ret.Values, v)
+	}
+	if err := s.Err(); err != nil {
+		return Trace{}, fmt.Errorf("trace %q: %w", name, err)
+	}
+	ret.Description = strings.Join(desc, " ")
+	return ret, nil
+}
@@ -0,0 +1,229 @@
+package corpus
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/danderson/go-lnds/lis"
+	diff "github.com/google/go-cmp/cmp"
+)
+
+var update = flag.Bool("update", false, "regenerate the embedded traces")
+
+// model is a generator for one synthetic trace.
+type model struct {
+	name string
+	desc string
+	gen  func(r *rand.Rand) []int
+}
+
+var models = []model{
+	{
+		name: "log_timestamps",
+		desc: "Synthetic. Timestamps of 5000 log lines from 4 hosts, in the order a collector received them, in milliseconds since the start of the log. Each host's clock is skewed by up to 10ms, and hosts ship lines in batches of 50 with up to 30ms of delay per batch.",
+		gen:  logTimestamps,
+	},
+	{
+		name: "ui_reorder",
+		desc: "Synthetic. IDs of 300 list items, initially in order, after a user moved 15 random items to random new positions by drag and drop.",
+		gen:  uiReorder,
+	},
+	{
+		name: "packet_seq",
+		desc: "Synthetic. Sequence numbers of 5000 packets in arrival order. 3% of packets overtake by 1 to 3 positions, as over multiple paths, and 0.5% arrive 20 to 100 positions late, as retransmissions.",
+		gen:  packetSeq,
+	},
+}
+
+func logTimestamps(r *rand.Rand) []int {
+	const (
+		numLines  = 5000
+		numHosts  = 4
+		maxSkew   = 10
+		batchSize = 50
+		maxDelay  = 30
+	)
+	skew := make([]int, numHosts)
+	for h := range skew {
+		skew[h] = r.IntN(2*maxSkew+1) - maxSkew
+	}
+
+	type line struct{ recorded, arrived int }
+	var (
+		lines   []line
+		now     = 0
+		counts  = make([]int, numHosts)
+		delays  = make([]int, numHosts)
+		arrival []int
+	)
+	for range numLines {
+		now += r.IntN(20)
+		h := r.IntN(numHosts)
+		if counts[h]%batchSize == 0 {
+			delays[h] = r.IntN(maxDelay + 1)
+		}
+		counts[h]++
+		lines = append(lines, line{now + skew[h], now + delays[h]})
+	}
+	slices.SortStableFunc(lines, func(a, b line) int { return cmp.Compare(a.arrived, b.arrived) })
+	for _, l := range lines {
+		arrival = append(arrival, l.recorded)
+	}
+	return arrival
+}
+
+func uiReorder(r *rand.Rand) []int {
+	const (
+		numItems = 300
+		numMoves = 15
+	)
+	items := make([]int, numItems)
+	for i := range items {
+		items[i] = i
+	}
+	for range numMoves {
+		from, to := r.IntN(numItems), r.IntN(numItems)
+		v := items[from]
+		items = slices.Delete(items, from, from+1)
+		items = slices.Insert(items, to, v)
+	}
+	return items
+}
+
+func packetSeq(r *rand.Rand) []int {
+	const numPackets = 5000
+	type packet struct{ seq, arrives int }
+	packets := make([]packet, numPackets)
+	for i := range packets {
+		packets[i] = packet{i, i}
+		switch p := r.Float64(); {
+		case p < 0.005:
+			packets[i].arrives += 20 + r.IntN(81)
+		case p < 0.035:
+			packets[i].arrives -= 1 + r.IntN(3)
+		}
+	}
+	slices.SortStableFunc(packets, func(a, b packet) int { return cmp.Compare(a.arrives, b.arrives) })
+	ret := make([]int, numPackets)
+	for i, p := range packets {
+		ret[i] = p.seq
+	}
+	return ret
+}
+
+// render formats a trace in the embedded file format.
+func render(m model, values []int) []byte {
+	var b strings.Builder
+	for _, line := range wrap(m.desc, 70) {
+		fmt.Fprintf(&b, "# %s\n", line)
+	}
+	for _, v := range values {
+		fmt.Fprintf(&b, "%d\n", v)
+	}
+	return []byte(b.String())
+}
+
+// wrap splits s into lines of at most width characters, at spaces.
+func wrap(s string, width int) []string {
+	var ret []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		if line != "" && len(line)+1+len(word) > width {
+			ret = append(ret, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	return append(ret, line)
+}
+
+func TestTracesUpToDate(t *testing.T) {
+	for i, m := range models {
+		values := m.gen(rand.New(rand.NewPCG(1, uint64(i))))
+		want := render(m, values)
+		file := filepath.Join("traces", m.name+".txt")
+
+		if *update {
+			if err := os.WriteFile(file, want, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		got, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s is stale, run go test -update", file)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	var wantNames []string
+	for _, m := range models {
+		wantNames = append(wantNames, m.name)
+	}
+	slices.Sort(wantNames)
+	if diff := diff.Diff(Names(), wantNames); diff != "" {
+		t.Errorf("Names() is wrong (-got+want):\n%s", diff)
+	}
+
+	for i, m := range models {
+		tr, err := Load(m.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tr.Description != m.desc {
+			t.Errorf("Load(%q) description = %q, want %q", m.name, tr.Description, m.desc)
+		}
+		want := m.gen(rand.New(rand.NewPCG(1, uint64(i))))
+		if diff := diff.Diff(tr.Values, want); diff != "" {
+			t.Errorf("Load(%q) values are wrong (-got+want):\n%s", m.name, diff)
+		}
+
+		// The traces are meant to be almost sorted: mostly in order,
+		// but not entirely.
+		n := len(tr.Values)
+		if l := lis.Len(tr.Values, cmp.Compare); l == n || l < n/2 {
+			t.Errorf("Load(%q) has longest increasing subsequence %d of %d, want almost sorted", m.name, l, n)
+		}
+	}
+
+	if _, err := Load("nonexistent"); err == nil {
+		t.Error("Load(nonexistent) succeeded, want error")
+	}
+}
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	all, err := All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(all), len(models); got != want {
+		t.Errorf("len(All()) = %d, want %d", got, want)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	t.Parallel()
+
+	if _, err := parse("bad", []byte("# desc\n1\nx\n")); err == nil {
+		t.Error("parse of non-integer line succeeded, want error")
+	}
+}
@@ -1,8 +1,5 @@
 module github.com/danderson/go-lnds
 
-go 1.22.3
+go 1.23
 
-require (
-	github.com/creachadair/mds v0.14.7 // indirect
-	github.com/google/go-cmp v0.6.0 // indirect
-)
+require github.com/google/go-cmp v0.6.0
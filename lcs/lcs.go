@@ -0,0 +1,214 @@
+// Package lcs computes the longest common subsequence (LCS) of two
+// slices: the longest list of elements that appears, in order but not
+// necessarily contiguously, in both.
+//
+// LCS is the basis of most diff algorithms. The elements of a that
+// are not in the common subsequence are the ones a diff deletes, and
+// the elements of b that are not in it are the ones a diff inserts.
+//
+// LCS works for any elements with an equality function, in quadratic
+// time. Sparse is much faster when few pairs of elements are equal,
+// which is typical of diffs, but needs comparable elements.
+package lcs
+
+import (
+	"cmp"
+
+	"github.com/danderson/go-lnds/lis"
+)
+
+// LCS computes a longest common subsequence of a and b, where eq
+// reports whether two elements are equal.
+//
+// common holds the elements of the common subsequence, taken from a.
+// restA and restB are the elements of a and b respectively that are
+// not part of the common subsequence. All three preserve the relative
+// order of their elements.
+//
+// If several longest common subsequences exist, LCS returns one of
+// them.
+//
+// LCS takes O(n·m) time and O(n+m) space for inputs of lengths n and
+// m, after trimming any common prefix and suffix, which takes linear
+// time.
+//
+// If a and b are both empty, LCS returns nil slices, as lis.LIS does
+// for empty input.
+func LCS[T any, S ~[]T](a, b S, eq func(T, T) bool) (common, restA, restB S) {
+	if len(a) == 0 && len(b) == 0 {
+		return nil, nil, nil
+	}
+	keepA, keepB, n := match(a, b, eq)
+	return split(a, b, keepA, keepB, n)
+}
+
+// split returns the common subsequence described by keepA and keepB,
+// which has length n, and the remainders of a and b, as for LCS.
+func split[T any, S ~[]T](a, b S, keepA, keepB []bool, n int) (common, restA, restB S) {
+	common = make(S, 0, n)
+	restA = make(S, 0, len(a)-n)
+	restB = make(S, 0, len(b)-n)
+	for i, v := range a {
+		if keepA[i] {
+			common = append(common, v)
+		} else {
+			restA = append(restA, v)
+		}
+	}
+	for j, v := range b {
+		if !keepB[j] {
+			restB = append(restB, v)
+		}
+	}
+	return common, restA, restB
+}
+
+// match computes a longest common subsequence of a and b, and returns
+// it as masks: keepA[i] and keepB[j] are true if a[i] and b[j] are in
+// the subsequence. n is the length of the subsequence.
+func match[T any, S ~[]T](a, b S, eq func(T, T) bool) (keepA, keepB []bool, n int) {
+	keepA = make([]bool, len(a))
+	keepB = make([]bool, len(b))
+
+	// Elements shared at the start or end of both inputs are always
+	// part of some longest common subsequence, and trimming them
+	// first is much cheaper than running them through the dynamic
+	// programming below.
+	start := 0
+	for start < len(a) && start < len(b) && eq(a[start], b[start]) {
+		keepA[start], keepB[start] = true, true
+		start++
+	}
+	endA, endB := len(a), len(b)
+	for endA > start && endB > start && eq(a[endA-1], b[endB-1]) {
+		endA--
+		endB--
+		keepA[endA], keepB[endB] = true, true
+	}
+	n = start + len(a) - endA
+
+	a, b = a[start:endA], b[start:endB]
+	if len(a) > 0 && len(b) > 0 {
+		rows := make([]int, 2*(len(b)+1))
+		n += hirschberg(a, b, eq, keepA[start:endA], keepB[start:endB], rows[:len(b)+1], rows[len(b)+1:])
+	}
+	return keepA, keepB, n
+}
+
+// hirschberg marks a longest common subsequence of a and b in keepA
+// and keepB, and returns its length, using Hirschberg's algorithm.
+//
+// The textbook dynamic program fills an n×m table of LCS lengths, so
+// that it can walk back through it to find the subsequence. Hirschberg
+// observes that the table's rows only depend on the row before, so
+// the LCS lengths of the top half of a with every prefix of b, and of
+// the bottom half of a with every suffix of b, take one row each. The
+// split of b that maximizes their sum is where a longest common
+// subsequence crosses from the top half of a to the bottom, and each
+// side can be solved recursively. That takes twice the time of the
+// textbook version, but only linear space.
+//
+// fwd and bwd are scratch space, which must have length len(b)+1.
+// The recursion doesn't need their contents once it has split b, so
+// every level reuses them.
+func hirschberg[T any, S ~[]T](a, b S, eq func(T, T) bool, keepA, keepB []bool, fwd, bwd []int) int {
+	switch {
+	case len(a) == 0 || len(b) == 0:
+		return 0
+	case len(a) == 1:
+		for j := range b {
+			if eq(a[0], b[j]) {
+				keepA[0], keepB[j] = true, true
+				return 1
+			}
+		}
+		return 0
+	}
+
+	mid := len(a) / 2
+	fwd, bwd = fwd[:len(b)+1], bwd[:len(b)+1]
+
+	// fwd[j] is the length of the LCS of a[:mid] and b[:j].
+	clear(fwd)
+	for _, x := range a[:mid] {
+		diag := 0 // the previous row's fwd[j-1]
+		for j := 1; j <= len(b); j++ {
+			up := fwd[j]
+			if eq(x, b[j-1]) {
+				fwd[j] = diag + 1
+			} else {
+				fwd[j] = max(up, fwd[j-1])
+			}
+			diag = up
+		}
+	}
+
+	// bwd[j] is the length of the LCS of a[mid:] and b[j:].
+	clear(bwd)
+	for i := len(a) - 1; i >= mid; i-- {
+		diag := 0 // the previous row's bwd[j+1]
+		for j := len(b) - 1; j >= 0; j-- {
+			down := bwd[j]
+			if eq(a[i], b[j]) {
+				bwd[j] = diag + 1
+			} else {
+				bwd[j] = max(down, bwd[j+1])
+			}
+			diag = down
+		}
+	}
+
+	split := 0
+	for j := range fwd {
+		if fwd[j]+bwd[j] > fwd[split]+bwd[split] {
+			split = j
+		}
+	}
+
+	return hirschberg(a[:mid], b[:split], eq, keepA[:mid], keepB[:split], fwd, bwd) +
+		hirschberg(a[mid:], b[split:], eq, keepA[mid:], keepB[split:], fwd, bwd)
+}
+
+// Sparse is like LCS, but requires comparable elements, and uses the
+// Hunt–Szymanski algorithm: it lists every pair of positions (i, j)
+// where a[i] == b[j], and finds a longest chain of pairs that
+// increase in both i and j, which is a longest strictly increasing
+// subsequence problem.
+//
+// Sparse takes O((n+m+r)·log(n+m)) time and O(m+r) space, where r is
+// the number of matching pairs. That is much faster than LCS when
+// matches are rare, such as when diffing lines of text, but can be
+// slower when they are common, with r approaching n·m when both
+// inputs are mostly the same repeated element.
+func Sparse[T comparable, S ~[]T](a, b S) (common, restA, restB S) {
+	if len(a) == 0 && len(b) == 0 {
+		return nil, nil, nil
+	}
+
+	// Elements of b by value, with positions in decreasing order.
+	// Visiting the matches for one element of a in decreasing order
+	// of j ensures that at most one of them can be in a strictly
+	// increasing subsequence of positions.
+	positions := map[T][]int{}
+	for j := len(b) - 1; j >= 0; j-- {
+		positions[b[j]] = append(positions[b[j]], j)
+	}
+
+	type pair struct{ i, j int }
+	var pairs []pair
+	for i, v := range a {
+		for _, j := range positions[v] {
+			pairs = append(pairs, pair{i, j})
+		}
+	}
+
+	chain := lis.LongestIndices(pairs, func(x, y pair) int { return cmp.Compare(x.j, y.j) }, lis.Strict)
+
+	keepA := make([]bool, len(a))
+	keepB := make([]bool, len(b))
+	for _, k := range chain {
+		p := pairs[k]
+		keepA[p.i], keepB[p.j] = true, true
+	}
+	return split(a, b, keepA, keepB, len(chain))
+}
@@ -0,0 +1,265 @@
+package lcs
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func eq(a, b int) bool { return a == b }
+
+func TestLCS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		a, b       []int
+		wantCommon []int
+		wantRestA  []int
+		wantRestB  []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "empty",
+			a:    []int{},
+			b:    []int{},
+		},
+		{
+			name:       "one_empty",
+			a:          []int{1, 2},
+			wantCommon: []int{},
+			wantRestA:  []int{1, 2},
+			wantRestB:  []int{},
+		},
+		{
+			name:       "equal",
+			a:          []int{1, 2, 3},
+			b:          []int{1, 2, 3},
+			wantCommon: []int{1, 2, 3},
+			wantRestA:  []int{},
+			wantRestB:  []int{},
+		},
+		{
+			name:       "disjoint",
+			a:          []int{1, 2},
+			b:          []int{3, 4},
+			wantCommon: []int{},
+			wantRestA:  []int{1, 2},
+			wantRestB:  []int{3, 4},
+		},
+		{
+			name:       "insert_delete",
+			a:          []int{1, 2, 3, 4, 5},
+			b:          []int{1, 3, 4, 6, 5},
+			wantCommon: []int{1, 3, 4, 5},
+			wantRestA:  []int{2},
+			wantRestB:  []int{6},
+		},
+		{
+			name:       "interior",
+			a:          []int{0, 7, 1, 8, 2, 9},
+			b:          []int{0, 1, 5, 2, 6, 9},
+			wantCommon: []int{0, 1, 2, 9},
+			wantRestA:  []int{7, 8},
+			wantRestB:  []int{5, 6},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			impls := map[string]func(a, b []int) ([]int, []int, []int){
+				"LCS":    func(a, b []int) ([]int, []int, []int) { return LCS(a, b, eq) },
+				"Sparse": Sparse[int, []int],
+			}
+			for name, fn := range impls {
+				gotCommon, gotRestA, gotRestB := fn(tc.a, tc.b)
+				if diff := diff.Diff(gotCommon, tc.wantCommon); diff != "" {
+					t.Errorf("%s common subsequence is wrong (-got+want):\n%s", name, diff)
+				}
+				if diff := diff.Diff(gotRestA, tc.wantRestA); diff != "" {
+					t.Errorf("%s remainder of a is wrong (-got+want):\n%s", name, diff)
+				}
+				if diff := diff.Diff(gotRestB, tc.wantRestB); diff != "" {
+					t.Errorf("%s remainder of b is wrong (-got+want):\n%s", name, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestLCSRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 10
+	const numIters = 200
+
+	for range numIters {
+		a, b := randomInts(rand.Intn(numVals+1)), randomInts(rand.Intn(numVals+1))
+
+		common, restA, restB := LCS(a, b, eq)
+		checkSplit(t, a, common, restA)
+		checkSplit(t, b, common, restB)
+		if got, want := len(common), bruteForceLen(a, b); got != want {
+			t.Errorf("len(LCS(%v, %v)) = %d, want %d", a, b, got, want)
+		}
+	}
+}
+
+func TestLCSLong(t *testing.T) {
+	t.Parallel()
+
+	// Long enough inputs that LCS recurses many levels deep, checked
+	// against the textbook dynamic program.
+	const numVals = 300
+	const numIters = 20
+
+	for range numIters {
+		a, b := randomInts(rand.Intn(numVals+1)), randomInts(rand.Intn(numVals+1))
+
+		common, restA, restB := LCS(a, b, eq)
+		checkSplit(t, a, common, restA)
+		checkSplit(t, b, common, restB)
+		if !isSubsequence(common, b) {
+			t.Errorf("LCS(%v, %v) = %v, which is not a subsequence of b", a, b, common)
+		}
+		if got, want := len(common), quadraticLen(a, b); got != want {
+			t.Errorf("len(LCS(%v, %v)) = %d, want %d", a, b, got, want)
+		}
+	}
+}
+
+func TestSparseRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 40
+	const numIters = 200
+
+	for range numIters {
+		a, b := randomInts(rand.Intn(numVals+1)), randomInts(rand.Intn(numVals+1))
+
+		common, restA, restB := Sparse(a, b)
+		checkSplit(t, a, common, restA)
+		checkSplit(t, b, common, restB)
+		want, _, _ := LCS(a, b, eq)
+		if got, want := len(common), len(want); got != want {
+			t.Errorf("len(Sparse(%v, %v)) = %d, want %d", a, b, got, want)
+		}
+	}
+}
+
+func BenchmarkSparse(b *testing.B) {
+	// Two versions of a 10k line file with a few edits, where lines
+	// are mostly unique: the case Sparse is designed for.
+	const n = 10_000
+	x := make([]int, n)
+	for i := range x {
+		x[i] = i
+	}
+	y := slices.Clone(x)
+	for i := 0; i < n; i += 1000 {
+		y[i] = -i
+	}
+
+	b.Run("LCS", func(b *testing.B) {
+		for range b.N {
+			LCS(x, y, eq)
+		}
+	})
+	b.Run("Sparse", func(b *testing.B) {
+		for range b.N {
+			Sparse(x, y)
+		}
+	})
+}
+
+// checkSplit checks that lst can be reconstructed by interleaving
+// common and rest, in order.
+func checkSplit(t *testing.T, lst, common, rest []int) {
+	t.Helper()
+	if len(common)+len(rest) != len(lst) {
+		t.Fatalf("%v split into %v and %v, wrong total length", lst, common, rest)
+	}
+	// reachable[k] is true if lst[:i] can be split into
+	// common[:k] and rest[:i-k].
+	reachable := make([]bool, len(common)+1)
+	reachable[0] = true
+	for i, v := range lst {
+		next := make([]bool, len(common)+1)
+		for k, ok := range reachable {
+			if !ok {
+				continue
+			}
+			if k < len(common) && common[k] == v {
+				next[k+1] = true
+			}
+			if r := i - k; r >= 0 && r < len(rest) && rest[r] == v {
+				next[k] = true
+			}
+		}
+		reachable = next
+	}
+	if !reachable[len(common)] {
+		t.Fatalf("%v is not an interleaving of %v and %v", lst, common, rest)
+	}
+}
+
+// bruteForceLen returns the length of the longest subsequence of a
+// that is also a subsequence of b.
+func bruteForceLen(a, b []int) int {
+	best := 0
+	for mask := range 1 << len(a) {
+		var seq []int
+		for i, v := range a {
+			if mask&(1<<i) != 0 {
+				seq = append(seq, v)
+			}
+		}
+		if isSubsequence(seq, b) {
+			best = max(best, len(seq))
+		}
+	}
+	return best
+}
+
+// quadraticLen returns the length of the longest common subsequence
+// of a and b, using the textbook dynamic program.
+func quadraticLen(a, b []int) int {
+	lens := make([][]int, len(a)+1)
+	for i := range lens {
+		lens[i] = make([]int, len(b)+1)
+	}
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				lens[i+1][j+1] = lens[i][j] + 1
+			} else {
+				lens[i+1][j+1] = max(lens[i][j+1], lens[i+1][j])
+			}
+		}
+	}
+	return lens[len(a)][len(b)]
+}
+
+func isSubsequence(seq, lst []int) bool {
+	k := 0
+	for _, v := range lst {
+		if k < len(seq) && seq[k] == v {
+			k++
+		}
+	}
+	return k == len(seq)
+}
+
+// randomInts returns n random values from a small alphabet, so that
+// the inputs have plenty in common.
+func randomInts(n int) []int {
+	ret := make([]int, n)
+	for i := range ret {
+		ret[i] = rand.Intn(4)
+	}
+	return ret
+}
@@ -0,0 +1,102 @@
+package lis
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/danderson/go-lnds/search"
+)
+
+// AllLIS returns an iterator over every longest increasing
+// subsequence of lst, whose elements must be totally ordered by cmp.
+// Each subsequence is yielded as the increasing list of its indices in
+// lst.
+//
+// Every subsequence is yielded exactly once, but subsequences with
+// different indices may have equal values. The order in which
+// subsequences are yielded is unspecified.
+//
+// There can be exponentially many longest subsequences, so the
+// iterator finds them one at a time, taking O(n·log(n)) time up front
+// and then O(L) time per subsequence of length L. lst must not be
+// modified while the iterator is in use.
+func AllLIS[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if len(lst) == 0 {
+			return
+		}
+
+		// Deal elements into piles, like weightedLongest does:
+		// pile k lists, in input order, every element that ends a
+		// subsequence of length k+1, and values in a pile are
+		// strictly decreasing. Pile k is elems[start[k]:start[k+1]].
+		//
+		// The possible predecessors of an element of pile k+1 are
+		// the elements of pile k that came before it and whose
+		// values don't exceed it. That's the intersection of a
+		// prefix and a suffix of pile k, which is the range of
+		// positions [predLo[i], predHi[i]) in pile k. size[k]
+		// counts the elements of pile k seen so far, which is
+		// where the prefix ends.
+		level, numPiles := dealLevels(lst, cmp)
+		start, elems := groupByLevel(level, numPiles)
+		pile := func(k int) []int {
+			return elems[start[k]:start[k+1]]
+		}
+		var (
+			size   = make([]int, numPiles)
+			predLo = make([]int, len(lst))
+			predHi = make([]int, len(lst))
+		)
+		for i, k := range level {
+			if k > 0 {
+				p := pile(k - 1)[:size[k-1]]
+				predHi[i] = len(p)
+				predLo[i] = search.LowerBound(len(p), func(pos int) int {
+					if cmp(lst[p[pos]], lst[i]) > 0 {
+						return -1
+					}
+					return 0
+				})
+			}
+			size[k]++
+		}
+
+		// Walk the tree of predecessor choices depth first, without
+		// recursion. At each level k, cur[k] is the position in
+		// pile k currently chosen, out of the range [cur[k],
+		// hi[k]) still to try.
+		var (
+			top = numPiles - 1
+			seq = make([]int, numPiles)
+			cur = make([]int, numPiles)
+			hi  = make([]int, numPiles)
+			k   = top
+		)
+		hi[top] = len(pile(top))
+		for {
+			if cur[k] == hi[k] {
+				// Exhausted this level, backtrack.
+				k++
+				if k > top {
+					return
+				}
+				cur[k]++
+				continue
+			}
+
+			i := pile(k)[cur[k]]
+			seq[k] = i
+			if k > 0 {
+				k--
+				cur[k], hi[k] = predLo[i], predHi[i]
+				continue
+			}
+
+			if !yield(slices.Clone(seq)) {
+				return
+			}
+			cur[0]++
+		}
+	}
+}
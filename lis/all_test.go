@@ -0,0 +1,123 @@
+package lis
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestAllLIS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   []int
+		want [][]int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "singleton",
+			in:   []int{1},
+			want: [][]int{{0}},
+		},
+		{
+			name: "backwards",
+			in:   []int{3, 2, 1},
+			want: [][]int{{0}, {1}, {2}},
+		},
+		{
+			name: "swapped_pairs",
+			in:   []int{2, 1, 4, 3},
+			want: [][]int{{0, 2}, {0, 3}, {1, 2}, {1, 3}},
+		},
+		{
+			name: "equal",
+			in:   []int{1, 1, 1},
+			want: [][]int{{0, 1, 2}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got [][]int
+			for seq := range AllLIS(tc.in, cmp.Compare) {
+				got = append(got, seq)
+			}
+			slices.SortFunc(got, slices.Compare)
+			if diff := diff.Diff(got, tc.want); diff != "" {
+				t.Errorf("AllLIS is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAllLISRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 12
+	const numIters = 100
+
+	for range numIters {
+		input := randomInts(numVals)
+		var got []string
+		for seq := range AllLIS(input, cmp.Compare) {
+			got = append(got, fmt.Sprint(seq))
+		}
+		slices.Sort(got)
+		want := bruteForceAllLIS(input)
+		if diff := diff.Diff(got, want); diff != "" {
+			t.Logf("Input: %v", input)
+			t.Errorf("AllLIS is wrong (-got+want):\n%s", diff)
+		}
+	}
+}
+
+func TestAllLISStop(t *testing.T) {
+	t.Parallel()
+
+	n := 0
+	for range AllLIS([]int{2, 1, 4, 3, 6, 5}, cmp.Compare) {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("iterated %d times, want 3", n)
+	}
+}
+
+// bruteForceAllLIS returns the index sets of all longest
+// non-decreasing subsequences of lst, formatted with fmt.Sprint and
+// sorted.
+func bruteForceAllLIS(lst []int) []string {
+	var (
+		best    int
+		bestSet []string
+	)
+	for mask := range 1 << len(lst) {
+		var idxs, seq []int
+		for i, v := range lst {
+			if mask&(1<<i) != 0 {
+				idxs = append(idxs, i)
+				seq = append(seq, v)
+			}
+		}
+		if !slices.IsSorted(seq) {
+			continue
+		}
+		if len(seq) > best {
+			best, bestSet = len(seq), nil
+		}
+		if len(seq) == best {
+			bestSet = append(bestSet, fmt.Sprint(idxs))
+		}
+	}
+	slices.Sort(bestSet)
+	return bestSet
+}
@@ -0,0 +1,53 @@
+package lis
+
+// LongestAlternating computes a longest alternating subsequence of
+// lst, whose elements must be totally ordered by cmp. In an
+// alternating (or "wiggle") subsequence, the differences between
+// consecutive elements strictly alternate in sign: each element is
+// either greater than both its neighbors in the subsequence, or less
+// than both. Any single element, and any two unequal elements, are
+// alternating.
+//
+// Like LIS, alternating is the subsequence and rest is the remaining
+// elements, both in their original relative order.
+//
+// If several longest alternating subsequences exist,
+// LongestAlternating returns the one that keeps the first element of
+// lst, and then the last element of each run of rising or falling
+// elements, i.e. the most extreme turning points.
+//
+// Unlike LIS, LongestAlternating runs in Θ(n) time.
+func LongestAlternating[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (alternating, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	// Greedily keep the turning points of lst: while elements keep
+	// moving in the same direction, the latest one replaces the
+	// previous one as the candidate turning point, since it leaves
+	// the most room for the next change of direction. Elements equal
+	// to the last kept one change nothing and are skipped.
+	var (
+		keep   = make([]bool, len(lst))
+		last   = 0 // index of the last kept element
+		dir    = 0 // sign of the last step, 0 if nothing kept yet
+		length = 1
+	)
+	keep[0] = true
+	for i := 1; i < len(lst); i++ {
+		c := cmp(lst[i], lst[last])
+		switch {
+		case c == 0:
+			continue
+		case (c > 0) == (dir > 0) && dir != 0:
+			keep[last] = false
+		default:
+			length++
+			dir = c
+		}
+		keep[i] = true
+		last = i
+	}
+
+	return partitionMask(lst, keep, length)
+}
@@ -0,0 +1,125 @@
+package lis
+
+import (
+	"cmp"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLongestAlternating(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		in       []int
+		wantAlt  []int
+		wantRest []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:     "one",
+			in:       []int{5},
+			wantAlt:  []int{5},
+			wantRest: []int{},
+		},
+		{
+			name:     "all_equal",
+			in:       []int{2, 2, 2},
+			wantAlt:  []int{2},
+			wantRest: []int{2, 2},
+		},
+		{
+			name:     "sorted",
+			in:       []int{1, 2, 3, 4},
+			wantAlt:  []int{1, 4},
+			wantRest: []int{2, 3},
+		},
+		{
+			name:     "already_wiggles",
+			in:       []int{1, 7, 4, 9, 2, 5},
+			wantAlt:  []int{1, 7, 4, 9, 2, 5},
+			wantRest: []int{},
+		},
+		{
+			name:     "runs",
+			in:       []int{1, 17, 5, 10, 13, 15, 10, 5, 16, 8},
+			wantAlt:  []int{1, 17, 5, 15, 5, 16, 8},
+			wantRest: []int{10, 13, 10},
+		},
+		{
+			name:     "equal_turn",
+			in:       []int{3, 3, 1, 1, 2},
+			wantAlt:  []int{3, 1, 2},
+			wantRest: []int{3, 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotAlt, gotRest := LongestAlternating(tc.in, cmp.Compare)
+			if diff := diff.Diff(gotAlt, tc.wantAlt); diff != "" {
+				t.Errorf("LongestAlternating subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LongestAlternating remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLongestAlternatingRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 12
+	const numIters = 200
+
+	for range numIters {
+		input := randomInts(numVals)
+		for i := range input {
+			input[i] %= 6
+		}
+
+		gotAlt, gotRest := LongestAlternating(input, cmp.Compare)
+		checkPartition(t, input, gotAlt, gotRest)
+		if !isAlternating(gotAlt) {
+			t.Fatalf("LongestAlternating(%v) = %v, not alternating", input, gotAlt)
+		}
+		if got, want := len(gotAlt), bruteForceAlternatingLen(input); got != want {
+			t.Errorf("len(LongestAlternating(%v)) = %d, want %d", input, got, want)
+		}
+	}
+}
+
+func isAlternating(lst []int) bool {
+	for i := 1; i < len(lst); i++ {
+		d := cmp.Compare(lst[i], lst[i-1])
+		if d == 0 {
+			return false
+		}
+		if i > 1 && d == cmp.Compare(lst[i-1], lst[i-2]) {
+			return false
+		}
+	}
+	return true
+}
+
+// bruteForceAlternatingLen returns the length of the longest
+// alternating subsequence of lst.
+func bruteForceAlternatingLen(lst []int) int {
+	best := 0
+	for mask := range 1 << len(lst) {
+		var seq []int
+		for i, v := range lst {
+			if mask&(1<<i) != 0 {
+				seq = append(seq, v)
+			}
+		}
+		if isAlternating(seq) {
+			best = max(best, len(seq))
+		}
+	}
+	return best
+}
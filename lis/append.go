@@ -0,0 +1,21 @@
+package lis
+
+import "slices"
+
+// AppendLIS is like LIS, but appends the longest increasing
+// subsequence to dstSorted and the remaining elements to dstRest, and
+// returns the extended slices. Callers that compute many LISes can
+// reuse output buffers across calls, in the style of the standard
+// library's append functions.
+func AppendLIS[T any, Slice ~[]T](dstSorted, dstRest, lst Slice, cmp func(T, T) int) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return dstSorted, dstRest
+	}
+
+	prev, last, length := longest(lst, cmp, false)
+	sortedStart, restStart := len(dstSorted), len(dstRest)
+	sorted = slices.Grow(dstSorted, length)[:sortedStart+length]
+	rest = slices.Grow(dstRest, len(lst)-length)[:restStart+len(lst)-length]
+	partitionInto(sorted[sortedStart:], rest[restStart:], lst, prev, last)
+	return sorted, rest
+}
@@ -0,0 +1,41 @@
+package lis
+
+import (
+	"cmp"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestAppendLIS(t *testing.T) {
+	t.Parallel()
+
+	var dstSorted, dstRest, wantSorted, wantRest []int
+	for _, input := range [][]int{nil, {1}, {4, 3, 2, 1}, randomInts(50), randomInts(50)} {
+		s, r := LIS(input, cmp.Compare)
+		wantSorted = append(wantSorted, s...)
+		wantRest = append(wantRest, r...)
+
+		dstSorted, dstRest = AppendLIS(dstSorted, dstRest, input, cmp.Compare)
+		if diff := diff.Diff(dstSorted, wantSorted); diff != "" {
+			t.Errorf("AppendLIS subsequence is wrong (-got+want):\n%s", diff)
+		}
+		if diff := diff.Diff(dstRest, wantRest); diff != "" {
+			t.Errorf("AppendLIS remainder is wrong (-got+want):\n%s", diff)
+		}
+	}
+}
+
+func TestAppendLISReusesBuffers(t *testing.T) {
+	input := randomInts(1000)
+	dstSorted := make([]int, 0, len(input))
+	dstRest := make([]int, 0, len(input))
+
+	// The only allocations left are the internal bookkeeping.
+	allocs := testing.AllocsPerRun(10, func() {
+		AppendLIS(dstSorted[:0], dstRest[:0], input, cmp.Compare)
+	})
+	if allocs > 2 {
+		t.Errorf("AppendLIS made %v allocations, want at most 2", allocs)
+	}
+}
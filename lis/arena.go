@@ -0,0 +1,30 @@
+//go:build goexperiment.arenas
+
+package lis
+
+import "arena"
+
+// LISArena is like LIS, but allocates its outputs and all of its
+// internal state from a, rather than from the garbage-collected heap.
+// Batch jobs that compute and discard many subsequences can use it to
+// free all that memory at once with a.Free, instead of leaving it to
+// the garbage collector.
+//
+// sorted and rest must not be used after a is freed.
+//
+// LISArena is only available when building with
+// GOEXPERIMENT=arenas.
+func LISArena[T any, Slice ~[]T](a *arena.Arena, lst Slice, cmp func(T, T) int) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	prev := arena.MakeSlice[int](a, len(lst), len(lst))
+	scratch := arena.MakeSlice[int](a, len(lst), len(lst))
+	prev, last, length := longestBuf(lst, cmp, false, 0, prev, scratch)
+
+	sorted = arena.MakeSlice[T](a, length, length)
+	rest = arena.MakeSlice[T](a, len(lst)-length, len(lst)-length)
+	partitionInto(sorted, rest, lst, prev, last)
+	return sorted, rest
+}
@@ -0,0 +1,41 @@
+//go:build goexperiment.arenas
+
+package lis
+
+import (
+	"arena"
+	"cmp"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+// TestLISArena checks LISArena against LIS. It only runs when
+// building with the arenas experiment:
+//
+//	GOEXPERIMENT=arenas go test ./lis
+func TestLISArena(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 100
+
+	a := arena.NewArena()
+	defer a.Free()
+
+	for range numIters {
+		input := randomInts(numVals)
+		wantSorted, wantRest := LIS(input, cmp.Compare)
+		gotSorted, gotRest := LISArena(a, input, cmp.Compare)
+		if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
+			t.Errorf("LISArena(%v) subsequence is wrong (-got+want):\n%s", input, diff)
+		}
+		if diff := diff.Diff(gotRest, wantRest); diff != "" {
+			t.Errorf("LISArena(%v) remainder is wrong (-got+want):\n%s", input, diff)
+		}
+	}
+
+	if gotSorted, gotRest := LISArena(a, []int(nil), cmp.Compare); gotSorted != nil || gotRest != nil {
+		t.Errorf("LISArena(nil) = %v, %v, want nil, nil", gotSorted, gotRest)
+	}
+}
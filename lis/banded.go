@@ -0,0 +1,24 @@
+package lis
+
+// BandedLIS is like LIS, but faster for inputs where every element is
+// known to be at most d positions away from where it would be in a
+// sorted copy of lst, for example a stream of packets subject to
+// bounded network reordering.
+//
+// Under that bound, each element takes O(log d) time to process
+// rather than O(log n). The result is always the same as LIS: if the
+// bound doesn't hold for some elements, those elements merely take
+// longer to process.
+func BandedLIS[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, d int) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	// An element x at input position i whose sorted position is
+	// within d of i can only be preceded in the input by fewer than
+	// 2d elements that compare greater than x. Every entry of tails
+	// after x's insertion point is such an element, so the insertion
+	// point is within 2d of the end of tails.
+	prev, last, length := longestBanded(lst, cmp, false, 2*max(d, 0))
+	return partition(lst, prev, last, length)
+}
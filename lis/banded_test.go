@@ -0,0 +1,80 @@
+package lis
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestBandedLIS(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 200
+	const numIters = 100
+
+	for range numIters {
+		d := rand.Intn(5)
+		input := nearlySorted(numVals, d)
+
+		// The bound is only a hint, so results must match LIS both
+		// when it holds and when it's too tight.
+		for _, band := range []int{0, d, d / 2, numVals} {
+			wantSorted, wantRest := LIS(input, cmp.Compare)
+			gotSorted, gotRest := BandedLIS(input, cmp.Compare, band)
+			if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
+				t.Logf("Input: %v, d=%d", input, band)
+				t.Errorf("BandedLIS subsequence differs from LIS (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, wantRest); diff != "" {
+				t.Logf("Input: %v, d=%d", input, band)
+				t.Errorf("BandedLIS remainder differs from LIS (-got+want):\n%s", diff)
+			}
+		}
+	}
+
+	for range numIters {
+		input := randomInts(50)
+		wantSorted, _ := LIS(input, cmp.Compare)
+		gotSorted, _ := BandedLIS(input, cmp.Compare, 1)
+		if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
+			t.Logf("Input: %v", input)
+			t.Errorf("BandedLIS subsequence differs from LIS (-got+want):\n%s", diff)
+		}
+	}
+}
+
+// nearlySorted returns a permutation of [0, n) (with a few repeated
+// values) in which every element is at most d positions from its
+// sorted position.
+func nearlySorted(n, d int) []int {
+	ret := make([]int, n)
+	for i := range ret {
+		ret[i] = i - i%3
+	}
+	// Shuffling within disjoint blocks of length d+1 moves each
+	// element by at most d.
+	for i := 0; i < n; i += d + 1 {
+		block := ret[i:min(i+d+1, n)]
+		rand.Shuffle(len(block), func(a, b int) { block[a], block[b] = block[b], block[a] })
+	}
+	return ret
+}
+
+func BenchmarkBandedLIS(b *testing.B) {
+	const n = 1_000_000
+	const d = 4
+	input := nearlySorted(n, d)
+
+	b.Run("LIS", func(b *testing.B) {
+		for range b.N {
+			LIS(input, cmp.Compare)
+		}
+	})
+	b.Run("BandedLIS", func(b *testing.B) {
+		for range b.N {
+			BandedLIS(input, cmp.Compare, d)
+		}
+	})
+}
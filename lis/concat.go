@@ -0,0 +1,96 @@
+package lis
+
+import "github.com/danderson/go-lnds/search"
+
+// A Coord is the position of an element in a slice of slices.
+type Coord struct {
+	// Shard is the index of the slice containing the element.
+	Shard int
+	// Index is the index of the element within its slice.
+	Index int
+}
+
+// LISConcat is like LISIndices, but computes the longest increasing
+// subsequence of the logical concatenation of shards, without
+// copying their elements into a single slice. Positions in the
+// result are given as coordinates into shards.
+//
+// Both coordinate slices are in increasing order of position in the
+// concatenation.
+//
+// LISConcat takes O(n·log(n)) time like LIS, independent of the
+// number of shards. Its bookkeeping is that of LISIndices, plus a
+// copy of the current tail element of each subsequence length, which
+// lets it compare elements without mapping positions back to shards.
+func LISConcat[T any, Slice ~[]T](shards []Slice, cmp func(T, T) int) (sorted, rest []Coord) {
+	n := 0
+	for _, shard := range shards {
+		n += len(shard)
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	// This is the loop of longestBuf, walking the shards in order.
+	// tails and prev hold positions in the concatenation, and
+	// tailVals[k] is the element at position tails[k]. Elements are
+	// only ever compared against tailVals, so positions never need
+	// to be mapped back to coordinates until the output is built.
+	var (
+		prev     = make([]int, n)
+		tails    = make([]int, 0, n)
+		tailVals = make([]T, 0, n)
+		pos      = 0
+	)
+	for _, shard := range shards {
+		for _, v := range shard {
+			switch {
+			case len(tails) == 0:
+				prev[pos] = -1
+				tails, tailVals = append(tails, pos), append(tailVals, v)
+			case cmp(v, tailVals[len(tailVals)-1]) >= 0:
+				prev[pos] = tails[len(tails)-1]
+				tails, tailVals = append(tails, pos), append(tailVals, v)
+			default:
+				replaceIdx := search.UpperBound(len(tailVals)-1, func(j int) int {
+					return cmp(tailVals[j], v)
+				})
+				prev[pos] = -1
+				if replaceIdx > 0 {
+					prev[pos] = tails[replaceIdx-1]
+				}
+				tails[replaceIdx], tailVals[replaceIdx] = pos, v
+			}
+			pos++
+		}
+	}
+
+	// The subsequence's positions are visited in decreasing order,
+	// so a shard cursor that only moves backwards turns them into
+	// coordinates. start is the position of shards[s][0].
+	length := len(tails)
+	sorted = make([]Coord, length)
+	s, start := len(shards)-1, n-len(shards[len(shards)-1])
+	for k, pos := length-1, tails[length-1]; k >= 0; k, pos = k-1, prev[pos] {
+		for pos < start {
+			s--
+			start -= len(shards[s])
+		}
+		sorted[k] = Coord{s, pos - start}
+	}
+
+	// sorted is in increasing order, so a single pass over the
+	// shards in lockstep with it finds everything else.
+	rest = make([]Coord, 0, n-length)
+	k := 0
+	for s, shard := range shards {
+		for i := range shard {
+			if c := (Coord{s, i}); k < length && sorted[k] == c {
+				k++
+			} else {
+				rest = append(rest, c)
+			}
+		}
+	}
+	return sorted, rest
+}
@@ -0,0 +1,86 @@
+package lis
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLISConcat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         [][]int
+		wantSorted []Coord
+		wantRest   []Coord
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "empty_shards",
+			in:   [][]int{{}, nil, {}},
+		},
+		{
+			name:       "one_shard",
+			in:         [][]int{{2, 1, 3}},
+			wantSorted: []Coord{{0, 1}, {0, 2}},
+			wantRest:   []Coord{{0, 0}},
+		},
+		{
+			name:       "spans_shards",
+			in:         [][]int{{1, 5}, {}, {2, 6}, {3}},
+			wantSorted: []Coord{{0, 0}, {2, 0}, {3, 0}},
+			wantRest:   []Coord{{0, 1}, {2, 1}},
+		},
+		{
+			name:       "trailing_empty",
+			in:         [][]int{{2, 1}, {3}, {}},
+			wantSorted: []Coord{{0, 1}, {1, 0}},
+			wantRest:   []Coord{{0, 0}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := LISConcat(tc.in, cmp.Compare)
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LISConcat subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LISConcat remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLISConcatRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 100
+
+	for range numIters {
+		input := randomInts(numVals)
+		var shards [][]int
+		for rest := input; len(rest) > 0; {
+			n := rand.Intn(len(rest) + 1)
+			shards = append(shards, rest[:n])
+			rest = rest[n:]
+		}
+
+		wantSorted, _ := LIS(input, cmp.Compare)
+		sortedCoords, _ := LISConcat(shards, cmp.Compare)
+		gotSorted := []int{}
+		for _, c := range sortedCoords {
+			gotSorted = append(gotSorted, shards[c.Shard][c.Index])
+		}
+		if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
+			t.Logf("Shards: %v", shards)
+			t.Errorf("LISConcat subsequence differs from LIS (-got+want):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package lis
+
+// MinCover returns the minimum number of non-decreasing subsequences
+// needed to cover lst, whose elements must be totally ordered by cmp.
+// That is the number of lanes needed to process out of order work in
+// order: deal each element into the first lane whose last element
+// doesn't compare greater, and MinCover lanes always suffice.
+//
+// By Dilworth's theorem, the answer is also the length of the
+// longest strictly decreasing subsequence of lst, which is how
+// MinCover computes it, at the same cost as Len.
+func MinCover[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) int {
+	return length(lst, reverse(cmp), true)
+}
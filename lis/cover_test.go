@@ -0,0 +1,66 @@
+package lis
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestMinCover(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   []int
+		want int
+	}{
+		{"nil", nil, 0},
+		{"sorted", []int{1, 2, 3}, 1},
+		{"equal", []int{2, 2, 2}, 1},
+		{"reversed", []int{3, 2, 1}, 3},
+		{"reversed_pairs", []int{3, 3, 2, 2, 1, 1}, 3},
+		{"two_lanes", []int{1, 5, 2, 6, 3, 7}, 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := MinCover(tc.in, cmp.Compare); got != tc.want {
+				t.Errorf("MinCover(%v) = %d, want %d", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMinCoverRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 100
+
+	for range numIters {
+		input := randomInts(numVals)
+		for i := range input {
+			input[i] %= 10
+		}
+
+		// Greedily dealing elements into lanes uses the minimum
+		// number of lanes.
+		var lanes []int // last element of each lane
+		for _, v := range input {
+			placed := false
+			for i, last := range lanes {
+				if last <= v {
+					lanes[i] = v
+					placed = true
+					break
+				}
+			}
+			if !placed {
+				lanes = append(lanes, v)
+			}
+		}
+
+		if got, want := MinCover(input, cmp.Compare), len(lanes); got != want {
+			t.Errorf("MinCover(%v) = %d, want %d", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package lis
+
+// LDS computes a longest strictly decreasing subsequence of lst, whose
+// elements must be totally ordered by cmp. Each element of the
+// subsequence compares less than the one before it.
+//
+// Like LIS, sorted is the subsequence and rest is the remaining
+// elements, both in their original relative order.
+func LDS[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (sorted, rest Slice) {
+	return Longest(lst, reverse(cmp), Strict)
+}
+
+// LNIS computes a longest non-increasing subsequence of lst, whose
+// elements must be totally ordered by cmp. Each element of the
+// subsequence compares less than or equal to the one before it.
+//
+// Like LIS, sorted is the subsequence and rest is the remaining
+// elements, both in their original relative order.
+func LNIS[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (sorted, rest Slice) {
+	return Longest(lst, reverse(cmp), NonStrict)
+}
+
+// reverse returns a comparator that orders elements in the opposite
+// order to cmp.
+func reverse[T any](cmp func(T, T) int) func(T, T) int {
+	return func(a, b T) int { return cmp(b, a) }
+}
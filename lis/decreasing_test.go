@@ -0,0 +1,162 @@
+package lis
+
+import (
+	"cmp"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLDS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		wantSorted []int
+		wantRest   []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "singleton",
+			in:         []int{1},
+			wantSorted: []int{1},
+			wantRest:   []int{},
+		},
+		{
+			name:       "sorted",
+			in:         []int{1, 2, 3, 4},
+			wantSorted: []int{4},
+			wantRest:   []int{1, 2, 3},
+		},
+		{
+			name:       "backwards",
+			in:         []int{4, 3, 2, 1},
+			wantSorted: []int{4, 3, 2, 1},
+			wantRest:   []int{},
+		},
+		{
+			name:       "equal",
+			in:         []int{2, 2, 2},
+			wantSorted: []int{2},
+			wantRest:   []int{2, 2},
+		},
+		{
+			name:       "organ_pipe",
+			in:         []int{1, 2, 3, 4, 3, 2, 1},
+			wantSorted: []int{4, 3, 2, 1},
+			wantRest:   []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := LDS(tc.in, cmp.Compare)
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LDS subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LDS remainder is wrong (-got+want):\n%s", diff)
+			}
+			checkPartition(t, tc.in, gotSorted, gotRest)
+		})
+	}
+}
+
+func TestLDSRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 100
+
+	for range numIters {
+		input := randomInts(numVals)
+		gotSorted, gotRest := LDS(input, cmp.Compare)
+		checkPartition(t, input, gotSorted, gotRest)
+
+		for i := 1; i < len(gotSorted); i++ {
+			if gotSorted[i-1] <= gotSorted[i] {
+				t.Fatalf("LDS(%v) = %v, not strictly decreasing", input, gotSorted)
+			}
+		}
+
+		neg := make([]int, len(input))
+		for i, v := range input {
+			neg[i] = -v
+		}
+		if got, want := len(gotSorted), quadraticStrictLen(neg); got != want {
+			t.Logf("Input: %v", input)
+			t.Errorf("len(LDS(x)) = %d, want %d", got, want)
+		}
+	}
+}
+
+func TestLNIS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		wantSorted []int
+		wantRest   []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "sorted",
+			in:         []int{1, 2, 3, 4},
+			wantSorted: []int{4},
+			wantRest:   []int{1, 2, 3},
+		},
+		{
+			name:       "equal",
+			in:         []int{2, 2, 2},
+			wantSorted: []int{2, 2, 2},
+			wantRest:   []int{},
+		},
+		{
+			name:       "countdown_with_resets",
+			in:         []int{5, 4, 4, 3, 9, 2, 1, 8, 0},
+			wantSorted: []int{5, 4, 4, 3, 2, 1, 0},
+			wantRest:   []int{9, 8},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := LNIS(tc.in, cmp.Compare)
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LNIS subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LNIS remainder is wrong (-got+want):\n%s", diff)
+			}
+			checkPartition(t, tc.in, gotSorted, gotRest)
+		})
+	}
+}
+
+func TestLNISRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 100
+
+	for range numIters {
+		input := randomInts(numVals)
+		gotSorted, gotRest := LNIS(input, cmp.Compare)
+		checkPartition(t, input, gotSorted, gotRest)
+
+		neg := make([]int, len(input))
+		for i, v := range input {
+			neg[i] = -v
+		}
+		if got, want := len(gotSorted), quadraticLen(neg); got != want {
+			t.Logf("Input: %v", input)
+			t.Errorf("len(LNIS(x)) = %d, want %d", got, want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package lis
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInfeasibleConstraint is the error wrapped by all errors reporting
+// that the constraints given to a function cannot all be satisfied.
+// Use errors.As with the specific error types to find out which
+// constraints conflict.
+var ErrInfeasibleConstraint = errors.New("constraints cannot be satisfied")
+
+// ConflictError is the error returned when two protected elements
+// are out of order with respect to each other, so that no increasing
+// subsequence can contain both of them.
+type ConflictError struct {
+	// I and J are the indices of the conflicting elements. I is less
+	// than J, but the element at I compares greater than the element
+	// at J.
+	I, J int
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("protected elements %d and %d are out of order", e.I, e.J)
+}
+
+// Unwrap returns ErrInfeasibleConstraint.
+func (e *ConflictError) Unwrap() error {
+	return ErrInfeasibleConstraint
+}
+
+// ErrTooLong is the error wrapped by all errors reporting that an
+// input would exceed the length limit set with WithMaxLen.
+var ErrTooLong = errors.New("input too long")
+
+// TooLongError is the error returned when adding an element would
+// make an input longer than the limit set with WithMaxLen.
+type TooLongError struct {
+	// MaxLen is the limit that would have been exceeded.
+	MaxLen int
+}
+
+func (e *TooLongError) Error() string {
+	return fmt.Sprintf("input would exceed the maximum length of %d elements", e.MaxLen)
+}
+
+// Unwrap returns ErrTooLong.
+func (e *TooLongError) Unwrap() error {
+	return ErrTooLong
+}
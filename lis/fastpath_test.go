@@ -0,0 +1,302 @@
+package lis_test
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/danderson/go-lnds/lcs"
+	"github.com/danderson/go-lnds/lis"
+	"github.com/danderson/go-lnds/patience"
+)
+
+// sortedInputVariants runs every entry point of the lis, lcs and
+// patience packages on lst, using cmp as the comparator where there is
+// one. Variants that find decreasing subsequences are given a strictly
+// decreasing input of the same length instead, and the lcs variants
+// compare lst with itself, so that every variant sees its best case.
+//
+// TestSortedInputCoversAPI fails if an exported function is missing
+// from this list.
+func sortedInputVariants(lst []int, cmp func(int, int) int) map[string]func() {
+	reversed := make([]int, len(lst))
+	for i := range reversed {
+		reversed[i] = len(lst) - i
+	}
+	ptrs := make([]*int, len(lst))
+	for i := range lst {
+		ptrs[i] = &lst[i]
+	}
+	floats := make([]float64, len(lst))
+	strs := make([]string, len(lst))
+	for i, v := range lst {
+		floats[i] = float64(v)
+		strs[i] = fmt.Sprintf("%08d", v)
+	}
+	never := func(int) bool { return false }
+	identity := func(v int) int { return v }
+	eq := func(a, b int) bool { return cmp(a, b) == 0 }
+
+	return map[string]func(){
+		"lis.LIS":            func() { lis.LIS(lst, cmp) },
+		"lis.Longest":        func() { lis.Longest(lst, cmp, lis.NonStrict) },
+		"lis.LongestIndices": func() { lis.LongestIndices(lst, cmp, lis.NonStrict) },
+		"lis.LongestLen":     func() { lis.LongestLen(lst, cmp, lis.NonStrict) },
+		"lis.LISIndices":     func() { lis.LISIndices(lst, cmp) },
+		"lis.Len":            func() { lis.Len(lst, cmp) },
+		"lis.AppendLIS":      func() { lis.AppendLIS(nil, nil, lst, cmp) },
+		"lis.BandedLIS":      func() { lis.BandedLIS(lst, cmp, 1) },
+		"lis.LISConcat":      func() { lis.LISConcat([][]int{lst[:len(lst)/2], lst[len(lst)/2:]}, cmp) },
+		"lis.LISNullable":    func() { lis.LISNullable(ptrs, cmp) },
+		"lis.LISProtected":   func() { lis.LISProtected(lst, cmp, never) },
+		"lis.LISPriority":    func() { lis.LISPriority(lst, cmp, func(int) int { return 1 }) },
+		"lis.LISLargest":     func() { lis.LISLargest(lst, cmp) },
+		"lis.LISWindow":      func() { lis.LISWindow(lst, cmp, len(lst)) },
+		"lis.SlidingLen":     func() { lis.SlidingLen(lst, cmp, lis.NonStrict, len(lst)) },
+		"lis.LISBy":          func() { lis.LISBy(lst, identity) },
+		"lis.Ordered":        func() { lis.Ordered(lst) },
+		"lis.LISStrings":     func() { lis.LISStrings(strs) },
+		"lis.LISMinDiff":     func() { lis.LISMinDiff(lst, identity, 0) },
+		"lis.LISMaxDiff":     func() { lis.LISMaxDiff(lst, identity, len(lst)) },
+		"lis.MSIS":           func() { lis.MSIS(lst) },
+		"lis.MinCover":       func() { lis.MinCover(lst, cmp) },
+		"lis.Snapshot":       func() { lis.Snapshot(lst, cmp, nil) },
+		"lis.LISSeq": func() {
+			sorted, rest := lis.LISSeq(lst, cmp)
+			for range sorted {
+			}
+			for range rest {
+			}
+		},
+		"lis.NewOnline": func() {
+			o := lis.NewOnline(cmp)
+			for _, v := range lst {
+				o.Push(v)
+			}
+			o.Current()
+		},
+		"lis.AllLIS": func() {
+			for range lis.AllLIS(lst, cmp) {
+			}
+		},
+		"lis.Trends":             func() { lis.Trends(lst, cmp) },
+		"lis.LongestAlternating": func() { lis.LongestAlternating(lst, cmp) },
+		"lis.LDS":                func() { lis.LDS(reversed, cmp) },
+		"lis.LNIS":               func() { lis.LNIS(reversed, cmp) },
+		"lis.LISFloat": func() {
+			lis.LISFloat(floats, lis.FloatOptions[float64]{})
+		},
+		"lcs.LCS":       func() { lcs.LCS(lst, lst, eq) },
+		"lcs.Sparse":    func() { lcs.Sparse(lst, lst) },
+		"patience.Deal": func() { patience.Deal(lst, cmp) },
+	}
+}
+
+// notVariants lists the exported functions that sortedInputVariants
+// deliberately leaves out, and why.
+var notVariants = map[string]string{
+	"lis.Intern":     "a helper for LISStrings, which covers it",
+	"lis.LISArena":   "needs an arena, and only exists with GOEXPERIMENT=arenas",
+	"lis.WithMaxLen": "an option for NewOnline, which covers it",
+}
+
+// uncounted lists the variants that take no comparator, so there is
+// nothing to count comparisons with. BenchmarkSortedInput still
+// reports their time per element.
+var uncounted = map[string]string{
+	"lis.LISBy":      "orders by key",
+	"lis.Ordered":    "compares with the native operators",
+	"lis.LISStrings": "compares interned strings",
+	"lis.LISMinDiff": "orders by key",
+	"lis.LISMaxDiff": "orders by key",
+	"lis.MSIS":       "compares numbers directly",
+	"lis.LISFloat":   "compares floats directly",
+	"lcs.Sparse":     "compares with ==",
+}
+
+// notLinear lists the variants that take more than linear time even
+// on sorted input, and why.
+var notLinear = map[string]string{
+	"lis.LISWindow":  "queries a segment tree for every element",
+	"lis.LISMinDiff": "queries a Fenwick tree for every element",
+	"lis.LISMaxDiff": "queries a segment tree for every element",
+	"lis.MSIS":       "queries a Fenwick tree for every element",
+	"lis.LISStrings": "sorts the distinct strings to intern them",
+	"lis.SlidingLen": "sorts the input to rank it",
+	"patience.Deal":  "sorted input makes one pile per element, and merging n piles takes O(n·logn)",
+}
+
+// allocsGrow lists the variants whose number of allocations grows
+// with the length of the input, and why.
+var allocsGrow = map[string]string{
+	"lis.NewOnline":  "Online can't know how many elements will be pushed, so its slices grow by appending",
+	"lis.Snapshot":   "renders every element to its own string",
+	"lis.LISStrings": "interns strings in a map, which grows with the input",
+	"lis.SlidingLen": "keeps the rows of its tableaux in slices that grow by appending",
+	"lcs.Sparse":     "indexes the positions of every value in a map",
+	"patience.Deal":  "returns one slice per pile, and sorted input makes one pile per element",
+}
+
+// maxAllocs is the number of allocations the core entry points may
+// make, on any input. Len-like functions allocate their bookkeeping
+// in one block. Functions that return elements allocate one block for
+// the bookkeeping and one for the results: results and bookkeeping
+// can't share a block, since they have different types, and results
+// sharing a block with the bookkeeping would keep it alive for as long
+// as the caller holds on to the results.
+var maxAllocs = map[string]float64{
+	"lis.Len":            1,
+	"lis.LongestLen":     1,
+	"lis.MinCover":       1,
+	"lis.LIS":            2,
+	"lis.Longest":        2,
+	"lis.LongestIndices": 2,
+	"lis.LISIndices":     2,
+	"lis.LDS":            2,
+	"lis.LNIS":           2,
+	"lis.BandedLIS":      2,
+	"lis.AppendLIS":      2,
+	"lis.Ordered":        2,
+	"lis.LISBy":          2,
+}
+
+func TestSortedInputIsLinear(t *testing.T) {
+	t.Parallel()
+
+	const n = 10_000
+	input := make([]int, n)
+	for i := range input {
+		input[i] = i / 2
+	}
+
+	// Every variant must process sorted input with a constant number
+	// of comparisons per element. A variant that loses its fast path
+	// does O(log n) comparisons per element, about 13 for this n.
+	const maxPerElement = 3
+
+	calls := 0
+	counting := func(a, b int) int {
+		calls++
+		return cmp.Compare(a, b)
+	}
+	for name, run := range sortedInputVariants(input, counting) {
+		if _, ok := uncounted[name]; ok {
+			continue
+		}
+		if _, ok := notLinear[name]; ok {
+			continue
+		}
+		calls = 0
+		run()
+		if calls > maxPerElement*n {
+			t.Errorf("%s made %d comparisons for %d sorted elements, want at most %d", name, calls, n, maxPerElement*n)
+		}
+	}
+}
+
+func TestSortedInputAllocs(t *testing.T) {
+	// Not parallel: AllocsPerRun counts allocations made by every
+	// goroutine.
+	if raceEnabled {
+		t.Skip("the race detector changes what escapes to the heap")
+	}
+
+	// Every variant must make the same number of allocations for a
+	// short and a long sorted input, and the core entry points must
+	// also stay within maxAllocs.
+	allocs := func(n int) map[string]float64 {
+		input := make([]int, n)
+		for i := range input {
+			input[i] = i
+		}
+		ret := map[string]float64{}
+		for name, run := range sortedInputVariants(input, cmp.Compare[int]) {
+			if _, ok := allocsGrow[name]; !ok {
+				ret[name] = testing.AllocsPerRun(20, run)
+			}
+		}
+		return ret
+	}
+	short, long := allocs(1_000), allocs(10_000)
+	for name, got := range long {
+		if want := short[name]; got != want {
+			t.Errorf("%s made %v allocations for 10000 sorted elements, but %v for 1000", name, got, want)
+		}
+		if want, ok := maxAllocs[name]; ok && got > want {
+			t.Errorf("%s made %v allocations, want at most %v", name, got, want)
+		}
+	}
+}
+
+func BenchmarkSortedInput(b *testing.B) {
+	for _, n := range []int{1_000, 1_000_000} {
+		input := make([]int, n)
+		for i := range input {
+			input[i] = i
+		}
+		variants := sortedInputVariants(input, cmp.Compare[int])
+		names := make([]string, 0, len(variants))
+		for name := range variants {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			run := variants[name]
+			b.Run(fmt.Sprintf("%s/%d", name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					run()
+				}
+				b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/float64(n), "ns/elem")
+			})
+		}
+	}
+}
+
+func TestSortedInputCoversAPI(t *testing.T) {
+	t.Parallel()
+
+	variants := sortedInputVariants(nil, cmp.Compare[int])
+	for _, dir := range []string{".", "../lcs", "../patience"} {
+		for _, name := range exportedFuncs(t, dir) {
+			_, isVariant := variants[name]
+			_, isExempt := notVariants[name]
+			if !isVariant && !isExempt {
+				t.Errorf("%s is not covered by sortedInputVariants", name)
+			}
+		}
+	}
+}
+
+// exportedFuncs returns the package-qualified names of the exported
+// top-level functions in the package in dir, as built with the
+// current build tags.
+func exportedFuncs(t *testing.T, dir string) []string {
+	t.Helper()
+
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ret []string
+	fset := token.NewFileSet()
+	for _, name := range pkg.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.IsExported() {
+				ret = append(ret, pkg.Name+"."+fn.Name.Name)
+			}
+		}
+	}
+	return ret
+}
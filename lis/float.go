@@ -0,0 +1,127 @@
+package lis
+
+import (
+	"cmp"
+	"math"
+)
+
+// A FloatPolicy says how LISFloat treats a class of special values.
+type FloatPolicy int
+
+const (
+	// First orders the values before all other values, and equal to
+	// each other.
+	First FloatPolicy = iota
+	// Last orders the values after all other values, and equal to
+	// each other.
+	Last
+	// Remove never includes the values in the sorted subsequence,
+	// and always places them in rest.
+	Remove
+	// Drop omits the values from both outputs.
+	Drop
+)
+
+// FloatOptions configures LISFloat.
+type FloatOptions[F ~float32 | ~float64] struct {
+	// NaN is the policy for NaN values.
+	NaN FloatPolicy
+	// IsMissing, if non-nil, reports whether a value is a sentinel
+	// for a missing value, for example a zero or a negative value in
+	// a column where those are impossible. NaNs are never considered
+	// missing.
+	IsMissing func(F) bool
+	// Missing is the policy for values for which IsMissing returns
+	// true.
+	Missing FloatPolicy
+}
+
+// LISFloat is like LIS, but for floating point values, with
+// configurable handling of NaNs and missing values.
+//
+// Without special handling, NaNs are not totally ordered and produce
+// meaningless results. LISFloat instead orders or filters them
+// according to opts. Infinities are ordered naturally, before and
+// after all finite values.
+//
+// If both NaNs and missing values are ordered First, missing values
+// sort before NaNs. If both are ordered Last, missing values sort
+// after NaNs.
+func LISFloat[F ~float32 | ~float64, Slice ~[]F](lst Slice, opts FloatOptions[F]) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	// Each element gets a rank according to its class, so that
+	// the candidates for the subsequence can be ordered by (rank,
+	// value). Within a rank, NaN and missing values compare equal.
+	const (
+		rankMissingFirst = iota
+		rankNaNFirst
+		rankNormal
+		rankNaNLast
+		rankMissingLast
+		rankRemove
+		rankDrop
+	)
+	policyRank := func(p FloatPolicy, first, last int) int {
+		switch p {
+		case First:
+			return first
+		case Last:
+			return last
+		case Remove:
+			return rankRemove
+		default:
+			return rankDrop
+		}
+	}
+	nanRank := policyRank(opts.NaN, rankNaNFirst, rankNaNLast)
+	missingRank := policyRank(opts.Missing, rankMissingFirst, rankMissingLast)
+	rankOf := func(v F) int {
+		switch {
+		case math.IsNaN(float64(v)):
+			return nanRank
+		case opts.IsMissing != nil && opts.IsMissing(v):
+			return missingRank
+		default:
+			return rankNormal
+		}
+	}
+
+	// candidates are the indices of the elements that can be part
+	// of the subsequence.
+	ranks := make([]int, len(lst))
+	candidates := make([]int, 0, len(lst))
+	for i, v := range lst {
+		ranks[i] = rankOf(v)
+		if ranks[i] < rankRemove {
+			candidates = append(candidates, i)
+		}
+	}
+
+	keep, length := longestOf(len(lst), candidates, func(a, b int) int {
+		if c := cmp.Compare(ranks[a], ranks[b]); c != 0 || ranks[a] != rankNormal {
+			return c
+		}
+		return cmp.Compare(lst[a], lst[b])
+	})
+
+	if opts.NaN != Drop && opts.Missing != Drop {
+		return partitionMask(lst, keep, length)
+	}
+
+	// Dropped elements are neither kept nor in rest, so filter them
+	// out before partitioning.
+	var (
+		filtered     = make(Slice, 0, len(lst))
+		filteredKeep = make([]bool, 0, len(lst))
+	)
+	for i, v := range lst {
+		if ranks[i] != rankDrop {
+			filtered = append(filtered, v)
+			filteredKeep = append(filteredKeep, keep[i])
+		}
+	}
+	return partitionMask(filtered, filteredKeep, length)
+}
@@ -0,0 +1,109 @@
+package lis
+
+import (
+	"math"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestLISFloat(t *testing.T) {
+	t.Parallel()
+
+	nan := math.NaN()
+	inf := math.Inf(1)
+	isZero := func(v float64) bool { return v == 0 }
+
+	tests := []struct {
+		name       string
+		in         []float64
+		opts       FloatOptions[float64]
+		wantSorted []float64
+		wantRest   []float64
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "no_specials",
+			in:         []float64{1, 3, 2, 4},
+			wantSorted: []float64{1, 2, 4},
+			wantRest:   []float64{3},
+		},
+		{
+			name:       "infinities",
+			in:         []float64{-inf, 1, inf, 2, 3},
+			wantSorted: []float64{-inf, 1, 2, 3},
+			wantRest:   []float64{inf},
+		},
+		{
+			name:       "nan_first",
+			in:         []float64{nan, 1, nan, 2, nan},
+			opts:       FloatOptions[float64]{NaN: First},
+			wantSorted: []float64{nan, nan, nan},
+			wantRest:   []float64{1, 2},
+		},
+		{
+			name:       "nan_last",
+			in:         []float64{nan, 1, nan, 2, nan},
+			opts:       FloatOptions[float64]{NaN: Last},
+			wantSorted: []float64{1, 2, nan},
+			wantRest:   []float64{nan, nan},
+		},
+		{
+			name:       "nan_remove",
+			in:         []float64{nan, 1, nan, 2, nan},
+			opts:       FloatOptions[float64]{NaN: Remove},
+			wantSorted: []float64{1, 2},
+			wantRest:   []float64{nan, nan, nan},
+		},
+		{
+			name:       "nan_drop",
+			in:         []float64{nan, 1, nan, 2, nan},
+			opts:       FloatOptions[float64]{NaN: Drop},
+			wantSorted: []float64{1, 2},
+			wantRest:   []float64{},
+		},
+		{
+			name:       "all_dropped",
+			in:         []float64{nan, nan},
+			opts:       FloatOptions[float64]{NaN: Drop},
+			wantSorted: []float64{},
+			wantRest:   []float64{},
+		},
+		{
+			name:       "missing_remove",
+			in:         []float64{1, 0, 2, 0, 3},
+			opts:       FloatOptions[float64]{IsMissing: isZero, Missing: Remove},
+			wantSorted: []float64{1, 2, 3},
+			wantRest:   []float64{0, 0},
+		},
+		{
+			name:       "missing_last_after_nan",
+			in:         []float64{nan, 0, 1},
+			opts:       FloatOptions[float64]{NaN: Last, IsMissing: isZero, Missing: Last},
+			wantSorted: []float64{nan, 0},
+			wantRest:   []float64{1},
+		},
+		{
+			name:       "missing_first_before_nan",
+			in:         []float64{0, nan, 1},
+			opts:       FloatOptions[float64]{NaN: First, IsMissing: isZero, Missing: First},
+			wantSorted: []float64{0, nan, 1},
+			wantRest:   []float64{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := LISFloat(tc.in, tc.opts)
+			if diff := diff.Diff(gotSorted, tc.wantSorted, cmpopts.EquateNaNs()); diff != "" {
+				t.Errorf("LISFloat subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest, cmpopts.EquateNaNs()); diff != "" {
+				t.Errorf("LISFloat remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package lis
+
+// LISIndices is like LIS, but returns the indices of the elements of
+// lst that are in the longest increasing subsequence, and the indices
+// of the remaining elements, rather than copies of the elements.
+//
+// Both index slices are in increasing order.
+func LISIndices[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (sorted, rest []int) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	prev, last, length := longest(lst, cmp, false)
+	sorted, rest = splitAt(make([]int, len(lst)), length)
+	for i, j := length-1, last; i >= 0; i, j = i-1, prev[j] {
+		sorted[i] = j
+	}
+	rest = rest[:0]
+	for i, j := 0, 0; i < len(lst); i++ {
+		if j < len(sorted) && sorted[j] == i {
+			j++
+		} else {
+			rest = append(rest, i)
+		}
+	}
+	return sorted, rest
+}
@@ -0,0 +1,80 @@
+package lis
+
+import (
+	"cmp"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLISIndices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		wantSorted []int
+		wantRest   []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "singleton",
+			in:         []int{1},
+			wantSorted: []int{0},
+			wantRest:   []int{},
+		},
+		{
+			name:       "backwards",
+			in:         []int{4, 3, 2, 1},
+			wantSorted: []int{3},
+			wantRest:   []int{0, 1, 2},
+		},
+		{
+			name:       "organ_pipe",
+			in:         []int{1, 2, 3, 4, 3, 2, 1},
+			wantSorted: []int{0, 1, 2, 4},
+			wantRest:   []int{3, 5, 6},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := LISIndices(tc.in, cmp.Compare)
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LISIndices subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LISIndices remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLISIndicesRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 100
+
+	for range numIters {
+		input := randomInts(numVals)
+		wantSorted, wantRest := LIS(input, cmp.Compare)
+		sortedIdx, restIdx := LISIndices(input, cmp.Compare)
+
+		gotSorted, gotRest := []int{}, []int{}
+		for _, i := range sortedIdx {
+			gotSorted = append(gotSorted, input[i])
+		}
+		for _, i := range restIdx {
+			gotRest = append(gotRest, input[i])
+		}
+		if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
+			t.Errorf("LISIndices subsequence differs from LIS (-got+want):\n%s", diff)
+		}
+		if diff := diff.Diff(gotRest, wantRest); diff != "" {
+			t.Errorf("LISIndices remainder differs from LIS (-got+want):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package lis
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Intern maps each distinct string in lst to a small integer, such
+// that the integers compare in the same order as the strings they
+// replace. ids[i] is the integer for lst[i], and table[ids[i]] ==
+// lst[i].
+//
+// Running any function in this package over ids with cmp.Compare
+// gives the same result (by index) as running it over lst, but
+// comparing integers is cheaper than comparing strings. For inputs
+// with few distinct values, such as log levels or enum names, the
+// one-off cost of interning is quickly recovered.
+func Intern[S ~[]string](lst S) (ids []int, table []string) {
+	byValue := make(map[string]int, len(lst))
+	for _, s := range lst {
+		if _, ok := byValue[s]; !ok {
+			byValue[s] = 0
+			table = append(table, s)
+		}
+	}
+	slices.Sort(table)
+	for i, s := range table {
+		byValue[s] = i
+	}
+
+	ids = make([]int, len(lst))
+	for i, s := range lst {
+		ids[i] = byValue[s]
+	}
+	return ids, table
+}
+
+// LISStrings is like LIS for a slice of strings ordered by
+// cmp.Compare, but interns the strings with Intern before running the
+// algorithm.
+func LISStrings[S ~[]string](lst S) (sorted, rest S) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	ids, _ := Intern(lst)
+	prev, last, length := longest(ids, cmp.Compare[int], false)
+	return partition(lst, prev, last, length)
+}
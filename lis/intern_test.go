@@ -0,0 +1,45 @@
+package lis
+
+import (
+	"cmp"
+	"fmt"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestIntern(t *testing.T) {
+	t.Parallel()
+
+	in := []string{"warn", "info", "error", "info", "warn"}
+	ids, table := Intern(in)
+	if diff := diff.Diff(table, []string{"error", "info", "warn"}); diff != "" {
+		t.Errorf("Intern table is wrong (-got+want):\n%s", diff)
+	}
+	if diff := diff.Diff(ids, []int{2, 1, 0, 1, 2}); diff != "" {
+		t.Errorf("Intern ids are wrong (-got+want):\n%s", diff)
+	}
+}
+
+func TestLISStrings(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 20
+
+	for range numIters {
+		var input []string
+		for _, v := range randomInts(numVals) {
+			input = append(input, fmt.Sprint(v%10))
+		}
+
+		wantSorted, wantRest := LIS(input, cmp.Compare)
+		gotSorted, gotRest := LISStrings(input)
+		if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
+			t.Errorf("LISStrings subsequence differs from LIS (-got+want):\n%s", diff)
+		}
+		if diff := diff.Diff(gotRest, wantRest); diff != "" {
+			t.Errorf("LISStrings remainder differs from LIS (-got+want):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package lis_test
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/danderson/go-lnds/lcs"
+	"github.com/danderson/go-lnds/lis"
+)
+
+// This test lives in an external test package because lcs depends on
+// lis.
+
+func TestLISAgainstLCS(t *testing.T) {
+	t.Parallel()
+
+	// A result from literature relates LIS and LCS:
+	//
+	//   len(LIS(lst)) == len(LCS(lst, Sorted(lst)))
+	//
+	// Check that this holds true. Ideally we could also compare the
+	// actual resultant lists, but there's no guarantee that LIS and
+	// LCS will return the _same_ longest increasing subsequence, if
+	// multiple options are available.
+
+	const numVals = 50
+	const numIters = 100
+	for i := 0; i < numIters; i++ {
+		input := make([]int, numVals)
+		for i := range input {
+			input[i] = rand.Intn(2 * numVals)
+		}
+
+		gotLIS, _ := lis.LIS(input, cmp.Compare)
+
+		sorted := append([]int(nil), input...)
+		slices.Sort(sorted)
+		gotLCS, _, _ := lcs.LCS(input, sorted, func(a, b int) bool { return a == b })
+
+		if got, want := len(gotLIS), len(gotLCS); got != want {
+			t.Logf("Input: %v", input)
+			t.Errorf("len(LIS(x)) = %v, want len(LCS(x, sorted(x))) = %v", got, want)
+		}
+	}
+}
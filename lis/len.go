@@ -0,0 +1,24 @@
+package lis
+
+// Len returns the length of a longest increasing subsequence of lst,
+// whose elements must be totally ordered by cmp.
+//
+// Len is cheaper than LIS: it does not build the subsequence or the
+// remaining elements, and makes a single allocation regardless of the
+// length of lst.
+func Len[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) int {
+	return LongestLen(lst, cmp, NonStrict)
+}
+
+// length is the length-only equivalent of longest. It runs the same
+// loop, but never reconstructs the subsequence.
+func length[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, strict bool) int {
+	if len(lst) == 0 {
+		return 0
+	}
+
+	// tails and prev share a single allocation.
+	buf := make([]int, 2*len(lst))
+	tails := extend(lst, cmp, strict, 0, buf[:0:len(lst)], buf[len(lst):], 0)
+	return len(tails)
+}
@@ -0,0 +1,57 @@
+package lis
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestLen(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   []int
+		want int
+	}{
+		{"nil", nil, 0},
+		{"singleton", []int{1}, 1},
+		{"sorted", []int{1, 2, 3, 4}, 4},
+		{"backwards", []int{4, 3, 2, 1}, 1},
+		{"organ_pipe", []int{1, 2, 3, 4, 3, 2, 1}, 4},
+		{"sawtooth", []int{0, 1, 0, -1, 0, 1, 0, -1}, 4},
+		{"run_of_equals", []int{2, 1, 3, 4, 3, 6, 3, 5, 8, 3, 7}, 6},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Len(tc.in, cmp.Compare); got != tc.want {
+				t.Errorf("Len(%v) = %d, want %d", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLenRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 100
+
+	for range numIters {
+		input := randomInts(numVals)
+		if got, want := Len(input, cmp.Compare), quadraticLen(input); got != want {
+			t.Logf("Input: %v", input)
+			t.Errorf("Len(x) = %d, want %d", got, want)
+		}
+	}
+}
+
+func TestLenAllocs(t *testing.T) {
+	input := randomInts(1000)
+	allocs := testing.AllocsPerRun(10, func() {
+		Len(input, cmp.Compare)
+	})
+	if allocs > 1 {
+		t.Errorf("Len made %v allocations, want at most 1", allocs)
+	}
+}
@@ -21,6 +21,11 @@
 // case, Θ(n) for the best case of an already sorted list, and
 // O(n·logn) in the average case.
 //
+// No function in this package recurses, including those that
+// reconstruct or enumerate subsequences, so stack usage stays small
+// and constant no matter how large the input or its longest
+// subsequence.
+//
 // The exact implementation is not guaranteed to remain the same, but
 // at present it uses the algorithm discovered by Fredman [1] and
 // Knuth [2]. At its core, it's the Schensted insertion
@@ -40,18 +45,67 @@
 // [3]: Craige Schensted, “Longest Increasing and Decreasing Subsequences,” Canadian Journal of Mathematics, vol. 13, pp. 179–191, 1961. Available: https://doi:10.4153/CJM-1961-015-3
 package lis
 
+import "github.com/danderson/go-lnds/search"
+
 // LIS computes a longest increasing subsequence of vs, whose elements
 // must be totally ordered by cmp.
+//
+// sorted is the longest increasing subsequence, and rest is the
+// remaining elements of lst. Both preserve the relative order in
+// which their elements appear in lst, so merging them back together
+// by original position reconstructs lst exactly.
+//
+// If several longest increasing subsequences exist, LIS returns the
+// one whose values are lexicographically smallest according to cmp.
+// In fact, each of its elements is the smallest that any longest
+// increasing subsequence has at that position, so for numeric values
+// it is also the one with the smallest sum: LIS keeps the cheapest
+// elements, and leaves the most expensive ones in rest.
+//
+// That subsequence is also the one whose indices in lst are
+// lexicographically greatest, i.e. the one that keeps the latest
+// possible elements. LISLargest makes the opposite choices.
 func LIS[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (sorted, rest Slice) {
-	if len(lst) == 0 {
-		return nil, nil
-	}
+	return Longest(lst, cmp, NonStrict)
+}
 
+// longest computes a longest increasing subsequence of lst, which
+// must not be empty. The subsequence is returned as a linked list:
+// last is the index of its final element, and prev[i] is the index
+// of the element preceding lst[i], or -1 if lst[i] is the first
+// element. length is the number of elements in the subsequence.
+//
+// prev[i] is only meaningful for elements of the returned
+// subsequence.
+//
+// If strict is true, longest computes a longest strictly increasing
+// subsequence instead.
+func longest[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, strict bool) (prev []int, last, length int) {
+	return longestBanded(lst, cmp, strict, 0)
+}
+
+// longestBanded is longest, with an optional hint that speeds up
+// inputs that are almost sorted. If band is positive, each element's
+// position in tails is first searched for among the last band
+// entries, before falling back to searching all of tails. The result
+// is the same regardless of band.
+func longestBanded[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, strict bool, band int) (prev []int, last, length int) {
+	// prev and scratch share a single allocation.
+	buf := make([]int, 2*len(lst))
+	return longestBuf(lst, cmp, strict, band, buf[:len(lst)], buf[len(lst):])
+}
+
+// longestBuf is longestBanded, using the caller-provided prev and
+// scratch as storage instead of allocating. Both must have the same
+// length as lst. The returned prev is the one passed in.
+func longestBuf[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, strict bool, band int, prev, scratch []int) ([]int, int, int) {
 	// Editorial note: "longest non-decreasing subsequence" is a
-	// mouthful, so the comments in this function omit
+	// mouthful, so the comments here and in extend omit
 	// "non-decreasing" and just say "subsequence" or "longest
 	// subsequence". This is unambiguous since we don't handle any
-	// subsequences other than the non-decreasing kind.
+	// subsequences other than the non-decreasing kind, except for
+	// the strict flag, whose (small) effect is noted where it
+	// applies.
 	//
 	// The algorithm's core is a loop over every element of lst. Each
 	// element we consider can be the beginning of a new subsequence
@@ -83,41 +137,57 @@ func LIS[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (sorted, rest Slice)
 	//     non-decreasing order can be processed in O(1) time rather
 	//     than O(log(n)).
 
-	var (
-		// tails[L] is the index into lst for the final element of a
-		// subsequence of length L. If several such subsequences
-		// exist, tails keeps whichever has the smallest final
-		// element, according to cmp.
-		tails = make([]int, 1, len(lst))
-
-		// prev[i] is the index into lst for the element that comes
-		// before lst[i] in a subsequence tracked by tails, or -1 if
-		// lst[i] is the first in a subsequence. It's effectively the
-		// pointers for the linked lists whose first elements are
-		// tracked in tails.
-		//
-		// tails by itself only gives us the length of the longest
-		// subsequence, and its final element. prev is the additional
-		// state we need to reconstruct the entire subsequence.
-		//
-		// prev[i]'s value is only valid if lst[i] is part of a
-		// subsequence currently being tracked in tails.
-		prev = make([]int, len(lst))
-	)
+	// tails[L] is the index into lst for the final element of a
+	// subsequence of length L. If several such subsequences
+	// exist, tails keeps whichever has the smallest final
+	// element, according to cmp.
+	tails := scratch[:0]
+
+	// prev[i] is the index into lst for the element that comes
+	// before lst[i] in a subsequence tracked by tails, or -1 if
+	// lst[i] is the first in a subsequence. It's effectively the
+	// pointers for the linked lists whose first elements are
+	// tracked in tails.
+	//
+	// tails by itself only gives us the length of the longest
+	// subsequence, and its final element. prev is the additional
+	// state we need to reconstruct the entire subsequence.
+	//
+	// prev[i]'s value is only valid if lst[i] is part of a
+	// subsequence currently being tracked in tails.
+
+	tails = extend(lst, cmp, strict, band, tails, prev, 0)
+	return prev, tails[len(tails)-1], len(tails)
+}
+
+// extend is the core loop of longestBuf, which Online and length also
+// use. It adds the elements of lst[from:] to the subsequences tracked
+// by tails, which must already account for lst[:from], records their
+// predecessors in prev, and returns the updated tails. strict and band
+// are as for longestBanded.
+func extend[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, strict bool, band int, tails, prev []int, from int) []int {
+	// An element extends a subsequence whose final element compares
+	// at least ext to it: less than it if strict, and less than or
+	// equal to it otherwise.
+	ext := 0
+	if strict {
+		ext = 1
+	}
 
-	for i := range lst {
-		if i == 0 {
-			// The rest of this loop is cleaner if it can assume that
-			// i-1 exists. This handles the initial edge case.
-			prev[0] = -1
-			tails[0] = 0
+	for i := from; i < len(lst); i++ {
+		if len(tails) == 0 {
+			// The rest of the loop is cleaner if it can assume that
+			// tails is not empty. This handles the initial edge
+			// case.
+			prev[i] = -1
+			tails = append(tails, i)
 			continue
 		}
 
 		idxOfBestTail := tails[len(tails)-1]
-		if cmp(lst[i], lst[idxOfBestTail]) >= 0 {
-			// Fast path: the i-th element extends the currently known
-			// longest subsequence.
+		if cmp(lst[i], lst[idxOfBestTail]) >= ext {
+			// Fast path: the i-th element extends the currently
+			// known longest subsequence.
 			prev[i] = idxOfBestTail
 			tails = append(tails, i)
 			continue
@@ -128,33 +198,73 @@ func LIS[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (sorted, rest Slice)
 		// subsequence is better than the one tails already knew
 		// about.
 		//
-		// Note we run the search over tails minus its final element,
-		// which might save one bisection. It doesn't change the
-		// outcome since the fast path eliminated the "beyond the end
-		// of tails" edge case.
-		replaceIdx := bisectRight(tails[:len(tails)-1], lst[i], func(idx int, target T) int {
-			return cmp(lst[idx], target)
-		})
+		// Note we run the search over tails minus its final
+		// element, which might save one bisection. It doesn't
+		// change the outcome since the fast path eliminated the
+		// "beyond the end of tails" edge case.
+		//
+		// If we were given a band, the search can be narrowed to
+		// the band when the tail just before it can be extended by
+		// the i-th element.
+		searchFrom := 0
+		if lo := len(tails) - 1 - band; band > 0 && lo > 0 {
+			if cmp(lst[i], lst[tails[lo-1]]) >= ext {
+				searchFrom = lo
+			}
+		}
+		replaceIdx := searchTails(lst, cmp, ext, tails, searchFrom, len(tails)-1, lst[i])
 
 		// The new element is extending the subsequence tracked in
 		// replaceIdx-1, replacing the previous best extension that
 		// was stored in replaceIdx. We have to deal with the edge
 		// case of the single-element subsequence.
-		if replaceIdx == 0 {
-			prev[i] = -1
-		} else {
+		prev[i] = -1
+		if replaceIdx > 0 {
 			prev[i] = tails[replaceIdx-1]
 		}
 		tails[replaceIdx] = i
 	}
+	return tails
+}
+
+// searchTails returns the first position k in [lo, hi) whose tail
+// lst[tails[k]] cannot be extended by x, or hi if there is none. ext
+// is as in extend: with ext 0, that's the first tail greater than x,
+// and with ext 1 (strict), the first tail greater than or equal to x,
+// since strictly increasing subsequences cannot be extended by equal
+// elements.
+//
+// This is search.UpperBound and search.LowerBound, specialized for
+// the core loop so that the compiler can inline it, rather than
+// calling through a closure for every probe.
+func searchTails[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, ext int, tails []int, lo, hi int, x T) int {
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if cmp(x, lst[tails[mid]]) < ext {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
 
-	// We can now iterate back through the longest subsequence and
-	// partition the input.
-	sorted = make([]T, len(tails))
-	rest = make([]T, len(lst)-len(tails))
+// partition splits lst into the subsequence described by prev, last
+// and length (as returned by longest), and the remaining elements.
+func partition[T any, Slice ~[]T](lst Slice, prev []int, last, length int) (sorted, rest Slice) {
+	sorted, rest = splitAt(make(Slice, len(lst)), length)
+	partitionInto(sorted, rest, lst, prev, last)
+	return sorted, rest
+}
+
+// partitionInto is like partition, but writes the outputs into sorted
+// and rest, which must have exactly the right lengths.
+func partitionInto[T any, Slice ~[]T](sorted, rest, lst Slice, prev []int, last int) {
+	// Iterate back through the longest subsequence and the input in
+	// lockstep, sending each element to the appropriate output.
 	var (
-		seqIdx    = tails[len(tails)-1] // current longest subsequence element
-		allIdx    = len(lst) - 1        // current input element
+		seqIdx    = last         // current longest subsequence element
+		allIdx    = len(lst) - 1 // current input element
 		sortedIdx = len(sorted) - 1
 		restIdx   = len(rest) - 1
 	)
@@ -184,28 +294,67 @@ output:
 			}
 		}
 	}
-
-	return sorted, rest
 }
 
-// bisectRight returns the position where target should be inserted in
-// a sorted slice. If target is already present in the slice, the
-// returned position is one past the final existing occurrence.
+// longestOf computes a longest increasing subsequence of the subset
+// of lst's elements whose indices are in idxs, which must be in
+// increasing order. cmp compares two elements of lst by index.
 //
-// This is effectively a right-leaning variant of
-// slices.BinarySearch. It doesn't return a found bool, since by
-// definition it will never return an index equivalent to target.
-func bisectRight[T, U any, Slice ~[]T](vs Slice, target U, cmp func(T, U) int) (idx int) {
-	ln := len(vs)
-	low, high := uint(0), uint(ln)
-	for low < high {
-		mid := (low + high) / 2
-		if cmp(vs[mid], target) > 0 {
-			high = mid
+// The result is returned as a mask: keep[i] is true if lst[i] is in
+// the subsequence. n is the length of lst.
+func longestOf(n int, idxs []int, cmp func(i, j int) int) (keep []bool, length int) {
+	keep = make([]bool, n)
+	if len(idxs) == 0 {
+		return keep, 0
+	}
+	prev, last, length := longest(idxs, cmp, false)
+	for i := last; i >= 0; i = prev[i] {
+		keep[idxs[i]] = true
+	}
+	return keep, length
+}
+
+// splitAt splits buf into two slices of lengths n and len(buf)-n,
+// which results use to share a single allocation. The first slice's
+// capacity is capped, so that appending to it can't overwrite the
+// second.
+func splitAt[T any, Slice ~[]T](buf Slice, n int) (Slice, Slice) {
+	return buf[:n:n], buf[n:]
+}
+
+// partitionMask splits lst into the elements for which keep is true
+// and the rest, like partition. length must be the number of true
+// entries in keep.
+func partitionMask[T any, Slice ~[]T](lst Slice, keep []bool, length int) (sorted, rest Slice) {
+	sorted, rest = splitAt(make(Slice, len(lst)), length)
+	sorted, rest = sorted[:0], rest[:0]
+	for i, v := range lst {
+		if keep[i] {
+			sorted = append(sorted, v)
 		} else {
-			low = mid + 1
+			rest = append(rest, v)
 		}
 	}
-	ret := int(low)
+	return sorted, rest
+}
+
+// indices returns the indices of the subsequence described by prev,
+// last and length (as returned by longest), in increasing order.
+func indices(prev []int, last, length int) []int {
+	ret := make([]int, length)
+	for i, j := length-1, last; i >= 0; i, j = i-1, prev[j] {
+		ret[i] = j
+	}
 	return ret
 }
+
+// upperBoundFast is search.UpperBound, with a fast path for targets
+// that belong at the very end. Searches over pile tops are usually
+// such searches for inputs that are mostly sorted, and the fast path
+// makes them O(1) instead of O(log n), like the fast path in longest.
+func upperBoundFast(n int, cmp func(i int) int) int {
+	if n == 0 || cmp(n-1) <= 0 {
+		return n
+	}
+	return search.UpperBound(n-1, cmp)
+}
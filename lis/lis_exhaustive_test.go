@@ -0,0 +1,55 @@
+//go:build exhaustive
+
+package lis
+
+import (
+	"cmp"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+// TestLISExhaustive checks LIS against quadraticLIS for every input
+// of length up to maxLen drawn from a small alphabet. It takes a
+// while, so it only runs when the exhaustive build tag is set:
+//
+//	go test -tags exhaustive ./...
+func TestLISExhaustive(t *testing.T) {
+	t.Parallel()
+
+	const maxLen = 10
+	const alphabet = 4
+
+	for n := 1; n <= maxLen; n++ {
+		forEachInput(n, alphabet, func(input []int) {
+			wantSorted := quadraticLIS(input)
+			gotSorted, gotRest := LIS(input, cmp.Compare)
+			checkPartition(t, input, gotSorted, gotRest)
+			if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
+				t.Fatalf("LIS(%v) subsequence is wrong (-got+want):\n%s", input, diff)
+			}
+		})
+	}
+}
+
+// forEachInput calls fn with every slice of length n whose elements
+// are in [0, alphabet). fn must not retain the slice, it is reused
+// between calls.
+func forEachInput(n, alphabet int, fn func([]int)) {
+	input := make([]int, n)
+	for {
+		fn(input)
+
+		// Increment input like an odometer, least significant digit
+		// last.
+		i := n - 1
+		for i >= 0 && input[i] == alphabet-1 {
+			input[i] = 0
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		input[i]++
+	}
+}
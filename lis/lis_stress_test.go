@@ -0,0 +1,73 @@
+//go:build stress
+
+package lis
+
+import (
+	"cmp"
+	"flag"
+	"runtime/debug"
+	"testing"
+)
+
+var stressLen = flag.Int("stress.len", 1e8, "input length for stress tests")
+
+// TestStress runs the variants that reconstruct long subsequences on
+// a huge, mostly sorted input, with the maximum goroutine stack size
+// lowered so that any reconstruction that recursed per element would
+// crash. It needs several GB of memory, so it only runs when the
+// stress build tag is set:
+//
+//	go test -tags stress -run Stress -timeout 1h ./lis
+//
+// The input length can be changed with -stress.len.
+func TestStress(t *testing.T) {
+	defer debug.SetMaxStack(debug.SetMaxStack(1 << 20))
+
+	// Every 16th element is out of place, so most of the input ends
+	// up in the subsequence, and reconstructing it walks a linked
+	// list of nearly stressLen elements.
+	n := *stressLen
+	input := make([]int32, n)
+	for i := range input {
+		input[i] = int32(i)
+		if i%16 == 15 {
+			input[i] = int32(i) - 100
+		}
+	}
+	want := n - n/16
+
+	t.Run("LIS", func(t *testing.T) {
+		sorted, rest := LIS(input, cmp.Compare)
+		if len(sorted) != want || len(rest) != n-want {
+			t.Errorf("LIS returned %d sorted and %d rest, want %d and %d", len(sorted), len(rest), want, n-want)
+		}
+	})
+	t.Run("LISIndices", func(t *testing.T) {
+		sorted, _ := LISIndices(input, cmp.Compare)
+		if len(sorted) != want {
+			t.Errorf("LISIndices returned %d indices, want %d", len(sorted), want)
+		}
+	})
+	t.Run("LISSeq", func(t *testing.T) {
+		sorted, _ := LISSeq(input, cmp.Compare)
+		got := 0
+		for range sorted {
+			got++
+		}
+		if got != want {
+			t.Errorf("LISSeq yielded %d elements, want %d", got, want)
+		}
+	})
+	t.Run("AllLIS", func(t *testing.T) {
+		// AllLIS keeps every pile, which costs several words per
+		// element on top of the input, so it gets a tenth of the
+		// input to stay within a reasonable memory budget.
+		input, want := input[:n/10], n/10-n/10/16
+		for idxs := range AllLIS(input, cmp.Compare) {
+			if len(idxs) != want {
+				t.Errorf("AllLIS yielded %d indices, want %d", len(idxs), want)
+			}
+			break
+		}
+	})
+}
@@ -6,7 +6,6 @@ import (
 	"slices"
 	"testing"
 
-	"github.com/creachadair/mds/slice"
 	diff "github.com/google/go-cmp/cmp"
 )
 
@@ -62,6 +61,14 @@ func TestLIS(t *testing.T) {
 			wantSorted: []int{0, 1, 3, 6, 7, 13, 20, 21, 22},
 			wantRest:   []int{2, 12, 11, 10},
 		},
+		{
+			name: "lexicographically_smallest",
+			// 1,2,3 and 1,2,4 and 1,3,3 and 1,3,4 are all
+			// longest, only the smallest is acceptable.
+			in:         []int{1, 3, 2, 4, 3},
+			wantSorted: []int{1, 2, 3},
+			wantRest:   []int{3, 4},
+		},
 		{
 			name:       "swapped_pairs",
 			in:         []int{2, 1, 4, 3, 6, 5, 8, 7},
@@ -86,6 +93,7 @@ func TestLIS(t *testing.T) {
 			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
 				t.Errorf("LIS remainder is wrong (-got+want):\n%s", diff)
 			}
+			checkPartition(t, tc.in, gotSorted, gotRest)
 			if t.Failed() {
 				t.Logf("Input was: %v", tc.in)
 				t.Logf("Got: %v, %v", gotSorted, gotRest)
@@ -95,57 +103,100 @@ func TestLIS(t *testing.T) {
 	}
 }
 
-func TestLISAgainstLCS(t *testing.T) {
+func TestLISRandom(t *testing.T) {
 	t.Parallel()
 
-	// A result from literature relates LIS and LCS:
-	//
-	//   len(LIS(lst)) == len(LCS(lst, Sorted(lst)))
-	//
-	// Check that this holds true. Ideally we could also compare the
-	// actual resultant lists, but there's no guarantee that LIS and
-	// LCS will return the _same_ longest increasing subsequence, if
-	// multiple options are available.
-
 	const numVals = 50
 	const numIters = 100
+
 	for i := 0; i < numIters; i++ {
 		input := randomInts(numVals)
+		wantSorted := quadraticLIS(input)
+		gotSorted, gotRest := LIS(input, cmp.Compare)
+		checkPartition(t, input, gotSorted, gotRest)
 
-		gotLIS, _ := LIS(input, cmp.Compare)
-
-		sorted := append([]int(nil), input...)
-		slices.Sort(sorted)
-		gotLCS := slice.LCS(input, sorted)
-
-		if got, want := len(gotLIS), len(gotLCS); got != want {
+		if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
 			t.Logf("Input: %v", input)
-			t.Errorf("len(LIS(x)) = %v, want len(LCS(x, sorted(x))) = %v", got, want)
+			t.Errorf("LIS subsequence is wrong (-got+want):\n%s", diff)
 		}
 	}
 }
 
-func TestLISRandom(t *testing.T) {
-	t.Parallel()
+func FuzzLIS(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{1, 2, 3, 4})
+	f.Add([]byte{4, 3, 2, 1})
+	f.Add([]byte{2, 1, 3, 4, 3, 6, 3, 5, 8, 3, 7})
 
-	const numVals = 50
-	const numIters = 100
+	f.Fuzz(func(t *testing.T, in []byte) {
+		sorted, rest := LIS(in, cmp.Compare)
 
-	for i := 0; i < numIters; i++ {
-		input := randomInts(numVals)
-		wantSorted := quadraticLIS(input)
-		gotSorted, _ := LIS(input, cmp.Compare)
+		checkPartition(t, in, sorted, rest)
+		if !slices.IsSorted(sorted) {
+			t.Errorf("LIS subsequence is not sorted: %v", sorted)
+		}
+		if got, want := len(sorted), quadraticLen(in); got != want {
+			t.Errorf("len(LIS) = %d, want %d", got, want)
+		}
+	})
+}
 
-		if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
-			t.Logf("Input: %v", input)
-			t.Errorf("LIS subsequence is wrong (-got+want):\n%s", diff)
+// checkPartition checks that sorted and rest are a partition of in
+// that preserves the relative order of elements, i.e. that in can be
+// reconstructed by interleaving sorted and rest.
+func checkPartition[T comparable](t *testing.T, in, sorted, rest []T) {
+	t.Helper()
+
+	if got, want := len(sorted)+len(rest), len(in); got != want {
+		t.Fatalf("len(sorted)+len(rest) = %d, want %d", got, want)
+	}
+
+	// Interleaving can be ambiguous when sorted and rest both have
+	// the same value next, so track every reachable (sortedIdx,
+	// restIdx) position rather than greedily picking one.
+	reachable := map[int]bool{0: true} // keyed by sortedIdx, restIdx is implied
+	for i, v := range in {
+		next := map[int]bool{}
+		for s := range reachable {
+			r := i - s
+			if s < len(sorted) && sorted[s] == v {
+				next[s+1] = true
+			}
+			if r < len(rest) && rest[r] == v {
+				next[s] = true
+			}
+		}
+		if len(next) == 0 {
+			t.Fatalf("sorted=%v and rest=%v do not interleave to form %v, mismatch at index %d", sorted, rest, in, i)
 		}
+		reachable = next
 	}
 }
 
+// quadraticLen returns the length of the longest non-decreasing
+// subsequence of lst, using the textbook O(n^2) dynamic programming
+// algorithm.
+func quadraticLen[T cmp.Ordered](lst []T) int {
+	ret := 0
+	lens := make([]int, len(lst))
+	for i := range lst {
+		lens[i] = 1
+		for j := range i {
+			if lst[j] <= lst[i] {
+				lens[i] = max(lens[i], lens[j]+1)
+			}
+		}
+		ret = max(ret, lens[i])
+	}
+	return ret
+}
+
 // quadraticLIS returns the same longest increasing subsequence of lst
 // that LIS() returns, but using a quadratic recursive search that is
 // much slower, but more obviously correct by inspection.
+//
+// In particular, among all longest subsequences it picks the one
+// with lexicographically smallest values, which LIS guarantees.
 func quadraticLIS(lst []int) []int {
 	// cmpSet orders a and b according to the best LIS. Longest lists
 	// go first, and within that equivalence class lists with smaller
@@ -208,3 +259,27 @@ func randomInts(N int) []int {
 	}
 	return ret
 }
+
+func BenchmarkLIS(b *testing.B) {
+	const n = 1_000_000
+	r := rand.New(rand.NewSource(1))
+	random := make([]int, n)
+	for i := range random {
+		random[i] = r.Intn(n)
+	}
+	sorted := make([]int, n)
+	for i := range sorted {
+		sorted[i] = i
+	}
+
+	b.Run("random", func(b *testing.B) {
+		for range b.N {
+			LIS(random, cmp.Compare)
+		}
+	})
+	b.Run("sorted", func(b *testing.B) {
+		for range b.N {
+			LIS(sorted, cmp.Compare)
+		}
+	})
+}
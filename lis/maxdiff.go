@@ -0,0 +1,75 @@
+package lis
+
+import "slices"
+
+// LISMaxDiff is like LISMinDiff, but with the opposite constraint:
+// each kept element's key must be at most g greater than the previous
+// kept element's key. This keeps the subsequence smooth, by refusing
+// to jump across outliers.
+//
+// key is called once for each element of lst. g must not be
+// negative. With g = 0, LISMaxDiff finds the longest run of equal
+// keys, not necessarily adjacent in lst.
+//
+// If several longest subsequences exist, LISMaxDiff prefers smaller
+// keys, like LIS. The results are meaningless if any key is NaN.
+//
+// LISMaxDiff runs in O(n·logn) time.
+func LISMaxDiff[T any, K Number, Slice ~[]T](lst Slice, key func(T) K, g K) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]K, len(lst))
+	for i, v := range lst {
+		keys[i] = key(v)
+	}
+
+	// As in LISMinDiff, but the valid predecessors of lst[i] are
+	// the earlier elements with keys in [keys[i]-g, keys[i]], which
+	// is a range of ranks rather than a prefix, and needs a segment
+	// tree instead of a Fenwick tree.
+	values := slices.Clone(keys)
+	slices.Sort(values)
+	values = slices.Compact(values)
+
+	var (
+		lens = make([]int, len(lst))
+		prev = make([]int, len(lst))
+		last = 0
+	)
+	better := func(i, j int) bool {
+		if lens[i] != lens[j] {
+			return lens[i] > lens[j]
+		}
+		if keys[i] != keys[j] {
+			return keys[i] < keys[j]
+		}
+		return i > j
+	}
+	tree := newRangeBest(len(values), better)
+	for i, k := range keys {
+		r, _ := slices.BinarySearch(values, k)
+
+		// For unsigned keys smaller than g, the bound wraps around,
+		// and every smaller key is in range.
+		lo := 0
+		if bound := k - g; bound <= k {
+			lo, _ = slices.BinarySearch(values, bound)
+		}
+
+		prev[i] = -1
+		lens[i] = 1
+		if best := tree.best(lo, r+1); best >= 0 {
+			prev[i] = best
+			lens[i] = lens[best] + 1
+		}
+
+		tree.add(r, i)
+		if better(i, last) {
+			last = i
+		}
+	}
+
+	return partition(lst, prev, last, lens[last])
+}
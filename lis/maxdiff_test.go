@@ -0,0 +1,126 @@
+package lis
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLISMaxDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		g          int
+		wantSorted []int
+		wantRest   []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "zero_is_equal_run",
+			in:         []int{1, 2, 1, 3, 1},
+			g:          0,
+			wantSorted: []int{1, 1, 1},
+			wantRest:   []int{2, 3},
+		},
+		{
+			name:       "reject_outlier",
+			in:         []int{1, 2, 100, 3, 4},
+			g:          2,
+			wantSorted: []int{1, 2, 3, 4},
+			wantRest:   []int{100},
+		},
+		{
+			name:       "no_jumps",
+			in:         []int{1, 10, 2, 20, 3},
+			g:          1,
+			wantSorted: []int{1, 2, 3},
+			wantRest:   []int{10, 20},
+		},
+		{
+			name:       "exact_gap",
+			in:         []int{0, 3, 7, 6},
+			g:          3,
+			wantSorted: []int{0, 3, 6},
+			wantRest:   []int{7},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := LISMaxDiff(tc.in, func(v int) int { return v }, tc.g)
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LISMaxDiff subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LISMaxDiff remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLISMaxDiffUnsigned(t *testing.T) {
+	t.Parallel()
+
+	// 2-5 wraps around, which must not be mistaken for a bound that
+	// rules out 0 as a predecessor.
+	in := []uint{0, 2, 9}
+	gotSorted, gotRest := LISMaxDiff(in, func(v uint) uint { return v }, 5)
+	if want := []uint{0, 2}; !slices.Equal(gotSorted, want) {
+		t.Errorf("LISMaxDiff(%v) = %v, want %v", in, gotSorted, want)
+	}
+	checkPartition(t, in, gotSorted, gotRest)
+}
+
+func TestLISMaxDiffRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 12
+	const numIters = 200
+
+	for range numIters {
+		input := randomInts(numVals)
+		g := rand.Intn(8)
+
+		gotSorted, gotRest := LISMaxDiff(input, func(v int) int { return v }, g)
+		checkPartition(t, input, gotSorted, gotRest)
+		for i := 1; i < len(gotSorted); i++ {
+			if step := gotSorted[i] - gotSorted[i-1]; step < 0 || step > g {
+				t.Fatalf("LISMaxDiff(%v, %d) = %v, has a step outside [0, %d]", input, g, gotSorted, g)
+			}
+		}
+		if got, want := len(gotSorted), bruteForceMaxDiffLen(input, g); got != want {
+			t.Errorf("len(LISMaxDiff(%v, %d)) = %d, want %d", input, g, got, want)
+		}
+	}
+}
+
+// bruteForceMaxDiffLen returns the length of the longest subsequence
+// of lst whose consecutive elements increase by at most g.
+func bruteForceMaxDiffLen(lst []int, g int) int {
+	best := 0
+	for mask := range 1 << len(lst) {
+		var seq []int
+		for i, v := range lst {
+			if mask&(1<<i) != 0 {
+				seq = append(seq, v)
+			}
+		}
+		ok := true
+		for i := 1; i < len(seq); i++ {
+			if step := seq[i] - seq[i-1]; step < 0 || step > g {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			best = max(best, len(seq))
+		}
+	}
+	return best
+}
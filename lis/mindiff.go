@@ -0,0 +1,80 @@
+package lis
+
+import "slices"
+
+// LISMinDiff is like LIS, but orders elements by a numeric key, and
+// requires consecutive elements of the subsequence to differ by at
+// least d: each kept element's key must be at least d greater than
+// the previous kept element's key. For example, with a key of sensor
+// readings, d is the smallest rise that counts as increasing.
+//
+// key is called once for each element of lst. d must not be
+// negative. With d = 0, LISMinDiff computes an ordinary longest
+// non-decreasing subsequence by key.
+//
+// If several longest subsequences exist, LISMinDiff prefers smaller
+// keys, like LIS, and when d is 0 it keeps the same values as LIS.
+// The results are meaningless if any key is NaN.
+//
+// LISMinDiff runs in O(n·logn) time.
+func LISMinDiff[T any, K Number, Slice ~[]T](lst Slice, key func(T) K, d K) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]K, len(lst))
+	for i, v := range lst {
+		keys[i] = key(v)
+	}
+
+	// lens[i] is the length of the longest subsequence ending at
+	// lst[i], which is one more than the longest ending at any
+	// earlier element whose key is at most keys[i]-d. Indexing
+	// elements by the rank of their key turns that into a prefix
+	// maximum, which a Fenwick tree answers in O(log n).
+	values := slices.Clone(keys)
+	slices.Sort(values)
+	values = slices.Compact(values)
+
+	var (
+		lens = make([]int, len(lst))
+		prev = make([]int, len(lst))
+		last = 0
+	)
+	// better prefers longer subsequences, then smaller keys, then
+	// later elements, which matches LIS's choices when d is 0.
+	better := func(i, j int) bool {
+		if lens[i] != lens[j] {
+			return lens[i] > lens[j]
+		}
+		if keys[i] != keys[j] {
+			return keys[i] < keys[j]
+		}
+		return i > j
+	}
+	tree := newPrefixBest(len(values), better)
+	for i, k := range keys {
+		prev[i] = -1
+		lens[i] = 1
+		// For unsigned keys smaller than d, the bound wraps around
+		// and there is no valid predecessor.
+		if bound := k - d; bound <= k {
+			n, found := slices.BinarySearch(values, bound)
+			if found {
+				n++
+			}
+			if best := tree.best(n); best >= 0 {
+				prev[i] = best
+				lens[i] = lens[best] + 1
+			}
+		}
+
+		r, _ := slices.BinarySearch(values, k)
+		tree.add(r, i)
+		if better(i, last) {
+			last = i
+		}
+	}
+
+	return partition(lst, prev, last, lens[last])
+}
@@ -0,0 +1,133 @@
+package lis
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLISMinDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		d          int
+		wantSorted []int
+		wantRest   []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "zero_is_lis",
+			in:         []int{1, 1, 2, 0, 3},
+			d:          0,
+			wantSorted: []int{1, 1, 2, 3},
+			wantRest:   []int{0},
+		},
+		{
+			name:       "one_is_strict",
+			in:         []int{1, 1, 2, 0, 3},
+			d:          1,
+			wantSorted: []int{1, 2, 3},
+			wantRest:   []int{1, 0},
+		},
+		{
+			name:       "skip_small_steps",
+			in:         []int{0, 1, 2, 5, 6, 10},
+			d:          4,
+			wantSorted: []int{0, 5, 10},
+			wantRest:   []int{1, 2, 6},
+		},
+		{
+			name:       "exact_step",
+			in:         []int{0, 3, 6, 8},
+			d:          3,
+			wantSorted: []int{0, 3, 6},
+			wantRest:   []int{8},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := LISMinDiff(tc.in, func(v int) int { return v }, tc.d)
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LISMinDiff subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LISMinDiff remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLISMinDiffUnsigned(t *testing.T) {
+	t.Parallel()
+
+	// 2-5 wraps around, which must not be mistaken for a bound
+	// that allows 9 as a predecessor.
+	in := []uint{9, 2, 8, 13}
+	gotSorted, gotRest := LISMinDiff(in, func(v uint) uint { return v }, 5)
+	if want := []uint{2, 8, 13}; !slices.Equal(gotSorted, want) {
+		t.Errorf("LISMinDiff(%v) = %v, want %v", in, gotSorted, want)
+	}
+	checkPartition(t, in, gotSorted, gotRest)
+}
+
+func TestLISMinDiffRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 12
+	const numIters = 200
+
+	for range numIters {
+		input := randomInts(numVals)
+		d := rand.Intn(6)
+
+		gotSorted, gotRest := LISMinDiff(input, func(v int) int { return v }, d)
+		checkPartition(t, input, gotSorted, gotRest)
+		for i := 1; i < len(gotSorted); i++ {
+			if gotSorted[i]-gotSorted[i-1] < d {
+				t.Fatalf("LISMinDiff(%v, %d) = %v, steps less than %d", input, d, gotSorted, d)
+			}
+		}
+		if got, want := len(gotSorted), bruteForceMinDiffLen(input, d); got != want {
+			t.Errorf("len(LISMinDiff(%v, %d)) = %d, want %d", input, d, got, want)
+		}
+		if d == 0 {
+			wantSorted, _ := LIS(input, cmp.Compare)
+			if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
+				t.Errorf("LISMinDiff(%v, 0) differs from LIS (-got+want):\n%s", input, diff)
+			}
+		}
+	}
+}
+
+// bruteForceMinDiffLen returns the length of the longest subsequence
+// of lst whose consecutive elements increase by at least d.
+func bruteForceMinDiffLen(lst []int, d int) int {
+	best := 0
+	for mask := range 1 << len(lst) {
+		var seq []int
+		for i, v := range lst {
+			if mask&(1<<i) != 0 {
+				seq = append(seq, v)
+			}
+		}
+		ok := true
+		for i := 1; i < len(seq); i++ {
+			if seq[i]-seq[i-1] < d {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			best = max(best, len(seq))
+		}
+	}
+	return best
+}
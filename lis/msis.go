@@ -0,0 +1,70 @@
+package lis
+
+import "slices"
+
+// MSIS computes a maximum-sum increasing subsequence of lst: of all
+// the increasing subsequences of lst, the one whose elements have the
+// greatest sum. Unlike LIS, the result need not be the longest
+// increasing subsequence: for [100, 1, 2, 3], the longest increasing
+// subsequence is [1, 2, 3], but the maximum-sum one is [100].
+//
+// As with LIS, sorted is non-decreasing, and sorted and rest
+// preserve the relative order of lst's elements. If lst is not
+// empty, sorted is not empty either: when every element is negative,
+// MSIS returns the single greatest element.
+//
+// If several subsequences have the same greatest sum, MSIS returns
+// one of them. The results are meaningless if lst contains NaNs.
+//
+// MSIS runs in O(n·logn) time.
+func MSIS[N Number, Slice ~[]N](lst Slice) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	// The classic dynamic programming solution computes sums[i], the
+	// greatest sum of an increasing subsequence ending at lst[i], as
+	// lst[i] plus the greatest sums[j] with j < i and lst[j] <=
+	// lst[i]. That's O(n²) done naively, but the inner maximum is a
+	// prefix maximum over values, if elements are indexed by the rank
+	// of their value rather than their position. A Fenwick tree over
+	// ranks answers that query in O(log n).
+	values := slices.Clone(lst)
+	slices.Sort(values)
+	values = slices.Compact(values)
+	rank := func(v N) int {
+		r, _ := slices.BinarySearch(values, v)
+		return r
+	}
+
+	var (
+		sums = make([]N, len(lst))
+		prev = make([]int, len(lst))
+		tree = newPrefixBest(len(values), func(i, j int) bool { return sums[i] > sums[j] })
+		last = 0
+	)
+	for i, v := range lst {
+		r := rank(v)
+
+		// Extending a subsequence whose sum isn't positive would
+		// only make things worse, so start a new one instead.
+		if best := tree.best(r + 1); best >= 0 && sums[best] > 0 {
+			sums[i] = sums[best] + v
+			prev[i] = best
+		} else {
+			sums[i] = v
+			prev[i] = -1
+		}
+
+		tree.add(r, i)
+		if sums[i] > sums[last] {
+			last = i
+		}
+	}
+
+	length := 0
+	for i := last; i >= 0; i = prev[i] {
+		length++
+	}
+	return partition(lst, prev, last, length)
+}
@@ -0,0 +1,130 @@
+package lis
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestMSIS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		wantSorted []int
+		wantRest   []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "one",
+			in:         []int{4},
+			wantSorted: []int{4},
+			wantRest:   []int{},
+		},
+		{
+			name:       "sorted",
+			in:         []int{1, 2, 3},
+			wantSorted: []int{1, 2, 3},
+			wantRest:   []int{},
+		},
+		{
+			name:       "big_first",
+			in:         []int{100, 1, 2, 3},
+			wantSorted: []int{100},
+			wantRest:   []int{1, 2, 3},
+		},
+		{
+			name:       "classic",
+			in:         []int{1, 101, 2, 3, 100, 4, 5},
+			wantSorted: []int{1, 2, 3, 100},
+			wantRest:   []int{101, 4, 5},
+		},
+		{
+			name:       "skip_negative",
+			in:         []int{-5, 1, -1, 2},
+			wantSorted: []int{1, 2},
+			wantRest:   []int{-5, -1},
+		},
+		{
+			name:       "all_negative",
+			in:         []int{-3, -1, -2},
+			wantSorted: []int{-1},
+			wantRest:   []int{-3, -2},
+		},
+		{
+			name:       "equal",
+			in:         []int{2, 2, 1, 2},
+			wantSorted: []int{2, 2, 2},
+			wantRest:   []int{1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := MSIS(tc.in)
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("MSIS subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("MSIS remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMSISRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 12
+	const numIters = 200
+
+	for range numIters {
+		input := make([]int, numVals)
+		for i := range input {
+			input[i] = rand.Intn(21) - 5
+		}
+
+		gotSorted, gotRest := MSIS(input)
+		checkPartition(t, input, gotSorted, gotRest)
+		if !slices.IsSorted(gotSorted) {
+			t.Fatalf("MSIS(%v) = %v, not sorted", input, gotSorted)
+		}
+		if got, want := sum(gotSorted), bruteForceMaxSum(input); got != want {
+			t.Errorf("MSIS(%v) = %v, sum %d, want sum %d", input, gotSorted, got, want)
+		}
+	}
+}
+
+func sum(lst []int) int {
+	total := 0
+	for _, v := range lst {
+		total += v
+	}
+	return total
+}
+
+// bruteForceMaxSum returns the greatest sum over all non-empty
+// non-decreasing subsequences of lst.
+func bruteForceMaxSum(lst []int) int {
+	best, found := 0, false
+	for mask := 1; mask < 1<<len(lst); mask++ {
+		var seq []int
+		for i, v := range lst {
+			if mask&(1<<i) != 0 {
+				seq = append(seq, v)
+			}
+		}
+		if !slices.IsSorted(seq) {
+			continue
+		}
+		if s := sum(seq); !found || s > best {
+			best, found = s, true
+		}
+	}
+	return best
+}
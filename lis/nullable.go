@@ -0,0 +1,34 @@
+package lis
+
+// LISNullable is like LIS, but for slices of pointers where a nil
+// element means "no value". Nil elements place no constraint on their
+// neighbors: they are always part of the sorted subsequence, and
+// never prevent non-nil elements on either side of them from being
+// part of it too.
+//
+// cmp is only ever called on non-nil elements.
+func LISNullable[T any, Slice ~[]*T](lst Slice, cmp func(T, T) int) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	candidates := make([]int, 0, len(lst))
+	nils := 0
+	for i, v := range lst {
+		if v == nil {
+			nils++
+		} else {
+			candidates = append(candidates, i)
+		}
+	}
+
+	keep, length := longestOf(len(lst), candidates, func(a, b int) int {
+		return cmp(*lst[a], *lst[b])
+	})
+	for i, v := range lst {
+		if v == nil {
+			keep[i] = true
+		}
+	}
+	return partitionMask(lst, keep, length+nils)
+}
@@ -0,0 +1,71 @@
+package lis
+
+import (
+	"cmp"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLISNullable(t *testing.T) {
+	t.Parallel()
+
+	ptrs := func(vs ...int) []*int {
+		ret := []*int{}
+		for _, v := range vs {
+			if v < 0 {
+				ret = append(ret, nil)
+			} else {
+				ret = append(ret, &v)
+			}
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name       string
+		in         []*int // -1 is nil
+		wantSorted []*int
+		wantRest   []*int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "all_nil",
+			in:         ptrs(-1, -1),
+			wantSorted: ptrs(-1, -1),
+			wantRest:   ptrs(),
+		},
+		{
+			name:       "no_nils",
+			in:         ptrs(1, 3, 2),
+			wantSorted: ptrs(1, 2),
+			wantRest:   ptrs(3),
+		},
+		{
+			name:       "holes",
+			in:         ptrs(1, -1, 2, -1, -1, 3, -1),
+			wantSorted: ptrs(1, -1, 2, -1, -1, 3, -1),
+			wantRest:   ptrs(),
+		},
+		{
+			name:       "holes_and_disorder",
+			in:         ptrs(-1, 4, -1, 1, 2, -1, 0, 3),
+			wantSorted: ptrs(-1, -1, 1, 2, -1, 3),
+			wantRest:   ptrs(4, 0),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := LISNullable(tc.in, cmp.Compare[int])
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LISNullable subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LISNullable remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package lis
+
+// Online computes a longest increasing subsequence incrementally, as
+// elements are pushed onto the end of the input.
+//
+// After any sequence of pushes, Current returns the same subsequence
+// that LIS would return for all the elements pushed so far. Each Push
+// runs one iteration of the same loop as LIS, and takes O(log n) time.
+//
+// An Online remembers every element pushed into it. Services that push
+// untrusted input should bound its memory use with WithMaxLen. It is
+// not safe for concurrent use.
+type Online[T any] struct {
+	cmp    func(T, T) int
+	maxLen int
+
+	// These fields are the same as the variables of the same name in
+	// longest, for the input lst.
+	lst   []T
+	tails []int
+	prev  []int
+}
+
+// NewOnline returns an empty Online whose elements are ordered by
+// cmp.
+func NewOnline[T any](cmp func(T, T) int, opts ...Option) *Online[T] {
+	var cfg options
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Online[T]{cmp: cmp, maxLen: cfg.maxLen}
+}
+
+// Push adds v to the end of the input. If the Online already holds as
+// many elements as its WithMaxLen limit allows, Push leaves it
+// unchanged and returns a *TooLongError.
+func (o *Online[T]) Push(v T) error {
+	i := len(o.lst)
+	if o.maxLen > 0 && i >= o.maxLen {
+		return &TooLongError{MaxLen: o.maxLen}
+	}
+	o.lst = append(o.lst, v)
+
+	o.prev = append(o.prev, 0)
+	o.tails = extend(o.lst, o.cmp, false, 0, o.tails, o.prev, i)
+	return nil
+}
+
+// Len returns the length of the current longest increasing
+// subsequence.
+func (o *Online[T]) Len() int {
+	return len(o.tails)
+}
+
+// Current returns the current longest increasing subsequence.
+func (o *Online[T]) Current() []T {
+	if len(o.tails) == 0 {
+		return nil
+	}
+
+	ret := make([]T, len(o.tails))
+	for i, j := len(ret)-1, o.tails[len(o.tails)-1]; i >= 0; i, j = i-1, o.prev[j] {
+		ret[i] = o.lst[j]
+	}
+	return ret
+}
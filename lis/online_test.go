@@ -0,0 +1,63 @@
+package lis
+
+import (
+	"cmp"
+	"errors"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestOnline(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 20
+
+	for range numIters {
+		input := randomInts(numVals)
+		o := NewOnline(cmp.Compare[int])
+		if got := o.Current(); got != nil {
+			t.Errorf("empty Online has Current() = %v, want nil", got)
+		}
+		for i, v := range input {
+			o.Push(v)
+			want, _ := LIS(input[:i+1], cmp.Compare)
+			if got := o.Len(); got != len(want) {
+				t.Fatalf("Len() after %d pushes = %d, want %d", i+1, got, len(want))
+			}
+			if diff := diff.Diff(o.Current(), want); diff != "" {
+				t.Logf("Input: %v", input[:i+1])
+				t.Fatalf("Current() differs from LIS (-got+want):\n%s", diff)
+			}
+		}
+	}
+}
+
+func TestOnlineMaxLen(t *testing.T) {
+	t.Parallel()
+
+	o := NewOnline(cmp.Compare[int], WithMaxLen(3))
+	for _, v := range []int{3, 1, 2} {
+		if err := o.Push(v); err != nil {
+			t.Fatalf("Push(%d) under the limit failed: %v", v, err)
+		}
+	}
+
+	err := o.Push(4)
+	var tooLong *TooLongError
+	if !errors.As(err, &tooLong) || tooLong.MaxLen != 3 || !errors.Is(err, ErrTooLong) {
+		t.Fatalf("Push over the limit returned %v, want a *TooLongError with MaxLen 3", err)
+	}
+	// The rejected element must not have been added.
+	if diff := diff.Diff(o.Current(), []int{1, 2}); diff != "" {
+		t.Errorf("Current() after a rejected Push is wrong (-got+want):\n%s", diff)
+	}
+
+	unlimited := NewOnline(cmp.Compare[int], WithMaxLen(0))
+	for v := range 10 {
+		if err := unlimited.Push(v); err != nil {
+			t.Fatalf("Push with no limit failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package lis
+
+// An Option configures a structure that accumulates elements over
+// time, such as Online.
+type Option func(*options)
+
+type options struct {
+	maxLen int
+}
+
+// WithMaxLen limits a structure to at most n elements. Adding more
+// fails with a *TooLongError, rather than growing memory use without
+// bound. An n of 0 or less means no limit, which is the default.
+//
+// Functions that take their whole input at once don't need a limit:
+// they all use memory linear in the length of the input, so callers
+// can check the length before calling.
+func WithMaxLen(n int) Option {
+	return func(o *options) { o.maxLen = n }
+}
@@ -0,0 +1,70 @@
+package lis
+
+import "cmp"
+
+// Ordered is like LIS, for slices of ordered types. Elements are
+// compared with cmp.Compare, so NaNs sort before all other values.
+//
+// Ordered computes the same subsequence as LIS with cmp.Compare, but
+// compares elements with the native operators rather than calling a
+// comparator, which makes it faster.
+func Ordered[T cmp.Ordered, Slice ~[]T](lst Slice) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	prev, last, length := orderedLongest(lst)
+	return partition(lst, prev, last, length)
+}
+
+// orderedLongest is longest, specialized for ordered types. Its loop
+// is that of extend, with cmp(a, b) >= 0 spelled !cmp.Less(a, b),
+// which inlines to a single comparison for types that have no NaNs.
+func orderedLongest[T cmp.Ordered, Slice ~[]T](lst Slice) (prev []int, last, length int) {
+	buf := make([]int, 2*len(lst))
+	prev, tails := buf[:len(lst)], buf[len(lst):len(lst)]
+
+	for i, x := range lst {
+		if len(tails) == 0 {
+			prev[i] = -1
+			tails = append(tails, i)
+			continue
+		}
+
+		idxOfBestTail := tails[len(tails)-1]
+		if !cmp.Less(x, lst[idxOfBestTail]) {
+			prev[i] = idxOfBestTail
+			tails = append(tails, i)
+			continue
+		}
+
+		// Find the first tail greater than x, as searchTails does.
+		lo, hi := 0, len(tails)-1
+		for lo < hi {
+			mid := int(uint(lo+hi) >> 1)
+			if cmp.Less(x, lst[tails[mid]]) {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+
+		prev[i] = -1
+		if lo > 0 {
+			prev[i] = tails[lo-1]
+		}
+		tails[lo] = i
+	}
+	return prev, tails[len(tails)-1], len(tails)
+}
+
+// LISBy is like LIS, but orders elements by the key that key extracts
+// from each element, rather than with a comparator.
+//
+// key may be called several times on each element, and should be
+// cheap.
+func LISBy[T any, K cmp.Ordered, Slice ~[]T](lst Slice, key func(T) K) (sorted, rest Slice) {
+	return LIS(lst, func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	})
+}
@@ -0,0 +1,110 @@
+package lis
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestOrdered(t *testing.T) {
+	t.Parallel()
+
+	// Ordered accepts named slice types and returns the same type.
+	type myStrings []string
+	in := myStrings{"b", "a", "c", "c", "b"}
+	gotSorted, gotRest := Ordered(in)
+	if diff := diff.Diff(gotSorted, myStrings{"a", "c", "c"}); diff != "" {
+		t.Errorf("Ordered subsequence is wrong (-got+want):\n%s", diff)
+	}
+	if diff := diff.Diff(gotRest, myStrings{"b", "b"}); diff != "" {
+		t.Errorf("Ordered remainder is wrong (-got+want):\n%s", diff)
+	}
+
+	for range 10 {
+		input := randomInts(50)
+		wantSorted, wantRest := LIS(input, cmp.Compare)
+		gotSorted, gotRest := Ordered(input)
+		if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
+			t.Errorf("Ordered subsequence differs from LIS (-got+want):\n%s", diff)
+		}
+		if diff := diff.Diff(gotRest, wantRest); diff != "" {
+			t.Errorf("Ordered remainder differs from LIS (-got+want):\n%s", diff)
+		}
+	}
+}
+
+func TestOrderedNaN(t *testing.T) {
+	t.Parallel()
+
+	// Ordered must agree with cmp.Compare, which sorts NaNs before
+	// all other values and equal to each other, even though the
+	// native operators don't.
+	nan := math.NaN()
+	vals := []float64{nan, -1, 0, 1, 2}
+	for range 100 {
+		input := make([]float64, 20)
+		for i := range input {
+			input[i] = vals[rand.Intn(len(vals))]
+		}
+		wantSorted, wantRest := LIS(input, cmp.Compare)
+		gotSorted, gotRest := Ordered(input)
+		if diff := diff.Diff(gotSorted, wantSorted, cmpopts.EquateNaNs()); diff != "" {
+			t.Logf("Input: %v", input)
+			t.Errorf("Ordered subsequence differs from LIS (-got+want):\n%s", diff)
+		}
+		if diff := diff.Diff(gotRest, wantRest, cmpopts.EquateNaNs()); diff != "" {
+			t.Logf("Input: %v", input)
+			t.Errorf("Ordered remainder differs from LIS (-got+want):\n%s", diff)
+		}
+	}
+}
+
+func TestLISBy(t *testing.T) {
+	t.Parallel()
+
+	type entry struct {
+		Name string
+		Seq  int
+	}
+	in := []entry{{"a", 1}, {"b", 3}, {"c", 2}, {"d", 4}}
+	gotSorted, gotRest := LISBy(in, func(e entry) int { return e.Seq })
+	if diff := diff.Diff(gotSorted, []entry{{"a", 1}, {"c", 2}, {"d", 4}}); diff != "" {
+		t.Errorf("LISBy subsequence is wrong (-got+want):\n%s", diff)
+	}
+	if diff := diff.Diff(gotRest, []entry{{"b", 3}}); diff != "" {
+		t.Errorf("LISBy remainder is wrong (-got+want):\n%s", diff)
+	}
+}
+
+func BenchmarkOrdered(b *testing.B) {
+	const n = 1_000_000
+	r := rand.New(rand.NewSource(1))
+	random := make([]int, n)
+	for i := range random {
+		random[i] = r.Intn(n)
+	}
+	sorted := make([]int, n)
+	for i := range sorted {
+		sorted[i] = i
+	}
+
+	for _, input := range []struct {
+		name string
+		lst  []int
+	}{{"random", random}, {"sorted", sorted}} {
+		b.Run("LIS/"+input.name, func(b *testing.B) {
+			for range b.N {
+				LIS(input.lst, cmp.Compare)
+			}
+		})
+		b.Run("Ordered/"+input.name, func(b *testing.B) {
+			for range b.N {
+				Ordered(input.lst)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package lis
+
+// dealLevels deals the elements of lst into patience piles, the way
+// longest does with tails: each element goes on the leftmost pile
+// whose top is greater than it, or on a new pile at the right if
+// there is none. It returns level[i], the pile that lst[i] went on,
+// and the number of piles.
+//
+// dealLevels only tracks the pile tops. Callers that need the piles
+// themselves lay them out with groupByLevel.
+func dealLevels[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (level []int, numPiles int) {
+	tops := make([]int, 0, len(lst)) // index into lst of each pile's top
+	level = make([]int, len(lst))
+	for i := range lst {
+		k := upperBoundFast(len(tops), func(k int) int {
+			return cmp(lst[tops[k]], lst[i])
+		})
+		if k == len(tops) {
+			tops = append(tops, i)
+		} else {
+			tops[k] = i
+		}
+		level[i] = k
+	}
+	return level, len(tops)
+}
+
+// groupByLevel groups the indices of level by their value, which
+// must be in [0, numLevels). Group k is elems[start[k]:start[k+1]],
+// in increasing order.
+//
+// Keeping every group in one shared array, rather than one slice per
+// group, makes the number of allocations independent of the number
+// of groups, which can be as large as len(level).
+func groupByLevel(level []int, numLevels int) (start, elems []int) {
+	start = make([]int, numLevels+1)
+	for _, k := range level {
+		start[k+1]++
+	}
+	for k := range numLevels {
+		start[k+1] += start[k]
+	}
+
+	// Use start[k] as the insertion cursor for group k. After the
+	// loop, each cursor has advanced to the start of the next group,
+	// so shifting start right by one restores it.
+	elems = make([]int, len(level))
+	for i, k := range level {
+		elems[start[k]] = i
+		start[k]++
+	}
+	copy(start[1:], start[:numLevels])
+	start[0] = 0
+	return start, elems
+}
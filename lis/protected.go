@@ -0,0 +1,67 @@
+package lis
+
+// LISProtected is like LIS, but computes a longest increasing
+// subsequence that contains every element for which protected
+// returns true. protected is called once for each index of lst.
+//
+// If the protected elements are not themselves in increasing order,
+// no such subsequence exists. LISProtected then returns a
+// *ConflictError identifying two adjacent protected elements that are
+// out of order, which wraps ErrInfeasibleConstraint.
+func LISProtected[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, protected func(i int) bool) (sorted, rest Slice, err error) {
+	if len(lst) == 0 {
+		return nil, nil, nil
+	}
+
+	// An unprotected element can only be in the subsequence if it
+	// fits between the protected elements on either side of it, so
+	// first find those neighbors for every element. lower[i] and
+	// upper[i] are the indices of the closest protected elements
+	// before and after i, or -1 if there are none.
+	var (
+		isProtected = make([]bool, len(lst))
+		lower       = make([]int, len(lst))
+		upper       = make([]int, len(lst))
+	)
+	lastProtected := -1
+	for i := range lst {
+		lower[i] = lastProtected
+		if protected(i) {
+			isProtected[i] = true
+			if lastProtected >= 0 && cmp(lst[lastProtected], lst[i]) > 0 {
+				return nil, nil, &ConflictError{lastProtected, i}
+			}
+			lastProtected = i
+		}
+	}
+	lastProtected = -1
+	for i := len(lst) - 1; i >= 0; i-- {
+		upper[i] = lastProtected
+		if isProtected[i] {
+			lastProtected = i
+		}
+	}
+
+	candidates := make([]int, 0, len(lst))
+	for i := range lst {
+		switch {
+		case isProtected[i]:
+		case lower[i] >= 0 && cmp(lst[lower[i]], lst[i]) > 0:
+			continue
+		case upper[i] >= 0 && cmp(lst[i], lst[upper[i]]) > 0:
+			continue
+		}
+		candidates = append(candidates, i)
+	}
+
+	// Every candidate fits between its protected neighbors, so
+	// adding a missing protected element to any increasing
+	// subsequence of the candidates yields a longer one. A longest
+	// subsequence of the candidates must therefore already contain
+	// all the protected elements.
+	keep, length := longestOf(len(lst), candidates, func(i, j int) int {
+		return cmp(lst[i], lst[j])
+	})
+	sorted, rest = partitionMask(lst, keep, length)
+	return sorted, rest, nil
+}
@@ -0,0 +1,154 @@
+package lis
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLISProtected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		protected  []int
+		wantSorted []int
+		wantRest   []int
+		wantErr    *ConflictError
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "nothing_protected",
+			in:         []int{2, 1, 3},
+			wantSorted: []int{1, 3},
+			wantRest:   []int{2},
+		},
+		{
+			name:       "protect_unpopular",
+			in:         []int{1, 2, 9, 3, 4},
+			protected:  []int{2},
+			wantSorted: []int{1, 2, 9},
+			wantRest:   []int{3, 4},
+		},
+		{
+			name:       "protect_several",
+			in:         []int{5, 1, 2, 6, 3, 4, 7},
+			protected:  []int{0, 3},
+			wantSorted: []int{5, 6, 7},
+			wantRest:   []int{1, 2, 3, 4},
+		},
+		{
+			name:      "conflict",
+			in:        []int{1, 5, 2, 3},
+			protected: []int{0, 1, 3},
+			wantErr:   &ConflictError{1, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest, err := LISProtected(tc.in, cmp.Compare, func(i int) bool {
+				return slices.Contains(tc.protected, i)
+			})
+			if tc.wantErr != nil {
+				if !errors.Is(err, ErrInfeasibleConstraint) {
+					t.Errorf("LISProtected err = %v, want ErrInfeasibleConstraint", err)
+				}
+				var conflict *ConflictError
+				if !errors.As(err, &conflict) {
+					t.Fatalf("LISProtected err = %v, want ConflictError", err)
+				}
+				if *conflict != *tc.wantErr {
+					t.Fatalf("LISProtected err = %v, want %v", conflict, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LISProtected err = %v", err)
+			}
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LISProtected subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LISProtected remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLISProtectedRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 12
+	const numIters = 200
+
+	for range numIters {
+		input := randomInts(numVals)
+
+		// Protect a random subset of LIS, so that a solution always
+		// exists.
+		sortedIdx, _ := LISIndices(input, cmp.Compare)
+		var protected []int
+		for _, i := range sortedIdx {
+			if rand.Intn(2) == 0 {
+				protected = append(protected, i)
+			}
+		}
+		// Also protect one random element, which may or may not
+		// conflict.
+		protected = append(protected, rand.Intn(len(input)))
+
+		gotSorted, gotRest, err := LISProtected(input, cmp.Compare, func(i int) bool {
+			return slices.Contains(protected, i)
+		})
+		want, feasible := quadraticProtectedLen(input, protected)
+		if !feasible {
+			if err == nil {
+				t.Errorf("LISProtected(%v, protected=%v) succeeded, want error", input, protected)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("LISProtected(%v, protected=%v) err = %v", input, protected, err)
+		}
+		checkPartition(t, input, gotSorted, gotRest)
+		if !slices.IsSorted(gotSorted) {
+			t.Errorf("LISProtected(%v) = %v, not sorted", input, gotSorted)
+		}
+		if got := len(gotSorted); got != want {
+			t.Errorf("len(LISProtected(%v, protected=%v)) = %d, want %d", input, protected, got, want)
+		}
+	}
+}
+
+// quadraticProtectedLen returns the length of the longest
+// non-decreasing subsequence of lst that contains all the indices in
+// protected, by brute force over every subsequence.
+func quadraticProtectedLen(lst []int, protected []int) (length int, feasible bool) {
+	best := -1
+	for mask := range 1 << len(lst) {
+		ok := true
+		for _, p := range protected {
+			if mask&(1<<p) == 0 {
+				ok = false
+			}
+		}
+		var seq []int
+		for i, v := range lst {
+			if mask&(1<<i) != 0 {
+				seq = append(seq, v)
+			}
+		}
+		if ok && slices.IsSorted(seq) {
+			best = max(best, len(seq))
+		}
+	}
+	return best, best >= 0
+}
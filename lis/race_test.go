@@ -0,0 +1,5 @@
+//go:build race
+
+package lis_test
+
+const raceEnabled = true
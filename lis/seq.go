@@ -0,0 +1,53 @@
+package lis
+
+import "iter"
+
+// LISSeq is like LIS, but returns iterators over the elements of the
+// longest increasing subsequence and over the remaining elements,
+// rather than copying them into new slices. Both iterators yield
+// each element's index in lst along with the element, in increasing
+// order of index.
+//
+// The subsequence is computed once, when LISSeq is called. The
+// iterators read from lst as they go, so lst must not be modified
+// while they are in use.
+func LISSeq[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (sorted, rest iter.Seq2[int, T]) {
+	// next[i] is the index of the element after lst[i] in the
+	// subsequence, or -1 if lst[i] is the last. Like prev, it's only
+	// meaningful for elements of the subsequence.
+	var (
+		next  []int
+		first = -1
+	)
+	if len(lst) > 0 {
+		prev, last, _ := longest(lst, cmp, false)
+
+		// Reverse the linked list in place, so that it can be
+		// walked forwards.
+		next = prev
+		for i := last; i >= 0; {
+			i, next[i], first = next[i], first, i
+		}
+	}
+
+	sorted = func(yield func(int, T) bool) {
+		for i := first; i >= 0; i = next[i] {
+			if !yield(i, lst[i]) {
+				return
+			}
+		}
+	}
+	rest = func(yield func(int, T) bool) {
+		seqIdx := first
+		for i, v := range lst {
+			if i == seqIdx {
+				seqIdx = next[seqIdx]
+				continue
+			}
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+	return sorted, rest
+}
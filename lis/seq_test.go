@@ -0,0 +1,54 @@
+package lis
+
+import (
+	"cmp"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLISSeq(t *testing.T) {
+	t.Parallel()
+
+	collect := func(seq func(func(int, int) bool)) (idxs, vals []int) {
+		idxs, vals = []int{}, []int{}
+		for i, v := range seq {
+			idxs = append(idxs, i)
+			vals = append(vals, v)
+		}
+		return idxs, vals
+	}
+
+	for _, input := range [][]int{nil, {1}, {4, 3, 2, 1}, randomInts(50), randomInts(50)} {
+		wantSortedIdx, wantRestIdx := LISIndices(input, cmp.Compare)
+		wantSorted, wantRest := LIS(input, cmp.Compare)
+		if input == nil {
+			wantSortedIdx, wantRestIdx = []int{}, []int{}
+			wantSorted, wantRest = []int{}, []int{}
+		}
+
+		sorted, rest := LISSeq(input, cmp.Compare)
+		gotSortedIdx, gotSorted := collect(sorted)
+		gotRestIdx, gotRest := collect(rest)
+		if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
+			t.Errorf("LISSeq subsequence differs from LIS (-got+want):\n%s", diff)
+		}
+		if diff := diff.Diff(gotRest, wantRest); diff != "" {
+			t.Errorf("LISSeq remainder differs from LIS (-got+want):\n%s", diff)
+		}
+		if diff := diff.Diff(gotSortedIdx, wantSortedIdx); diff != "" {
+			t.Errorf("LISSeq subsequence indices differ from LISIndices (-got+want):\n%s", diff)
+		}
+		if diff := diff.Diff(gotRestIdx, wantRestIdx); diff != "" {
+			t.Errorf("LISSeq remainder indices differ from LISIndices (-got+want):\n%s", diff)
+		}
+
+		// Iterators can be stopped early and reused.
+		for range sorted {
+			break
+		}
+		if again, _ := collect(sorted); len(again) != len(gotSortedIdx) {
+			t.Errorf("second iteration of sorted yielded %d elements, want %d", len(again), len(gotSortedIdx))
+		}
+	}
+}
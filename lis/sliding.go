@@ -0,0 +1,153 @@
+package lis
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/danderson/go-lnds/search"
+)
+
+// SlidingLen returns the length of a longest increasing subsequence,
+// with the given strictness, of every window of w consecutive
+// elements of lst: ret[i] is LongestLen(lst[i:i+w], cmp, s). If w is
+// less than 1 or greater than len(lst), there are no windows, and
+// SlidingLen returns nil.
+//
+// Unlike LISWindow, which limits the distance between consecutive
+// elements of a single subsequence, SlidingLen considers each window
+// separately, which suits monitoring how sorted a stream is over a
+// rolling window.
+//
+// Recomputing every window from scratch takes O(n·w·logw) time.
+// SlidingLen instead maintains the Robinson–Schensted tableaux of the
+// window as it slides, in O(n·logn + n·w) time in the worst case,
+// which sorted and reverse sorted inputs reach. It is usually much
+// faster: each slide costs time proportional to the number of rows
+// and columns of the tableaux, which for random inputs is about 2·√w
+// each. For windows of a few dozen elements or less, recomputing
+// each window with LongestLen is faster.
+func SlidingLen[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, s Strictness, w int) []int {
+	if w < 1 || w > len(lst) {
+		return nil
+	}
+
+	// Rank the elements so that their ranks are distinct, and a
+	// subsequence is increasing with the requested strictness
+	// exactly when its ranks are strictly increasing. Equal elements
+	// are ranked by index for non-strict subsequences, so that they
+	// can follow each other, and in reverse for strict ones, so that
+	// they can't.
+	order := make([]int, len(lst))
+	for i := range order {
+		order[i] = i
+	}
+	if s == Strict {
+		slices.Reverse(order)
+	}
+	slices.SortStableFunc(order, func(i, j int) int { return cmp(lst[i], lst[j]) })
+	rank := make([]int, len(lst))
+	for r, i := range order {
+		rank[i] = r
+	}
+
+	var t tableaux
+	ret := make([]int, len(lst)-w+1)
+	for i := range lst {
+		t.push(rank[i], i)
+		if i >= w {
+			t.popFirst()
+		}
+		if i >= w-1 {
+			ret[i-w+1] = t.len()
+		}
+	}
+	return ret
+}
+
+// tableaux are the Robinson–Schensted tableaux of a sequence of
+// distinct ranks: p is the insertion tableau, whose first row has the
+// length of the sequence's longest increasing subsequence, and q is
+// the recording tableau, which holds the position in the input of the
+// element whose insertion added each cell. Both have the same shape,
+// and are stored as rows.
+//
+// Appending an element to the sequence is the usual row insertion.
+// Removing its first element uses Schützenberger's observation that
+// the new q is the old q with its smallest entry deleted by jeu de
+// taquin, which vacates one cell of the shape. Running column
+// insertion backwards from that cell of p then ejects the first
+// element, and leaves the p of the remaining sequence, since column
+// insertion is how p changes when an element is prepended.
+type tableaux struct {
+	p, q [][]int
+}
+
+// len returns the length of the longest increasing subsequence of the
+// sequence.
+func (t *tableaux) len() int {
+	if len(t.p) == 0 {
+		return 0
+	}
+	return len(t.p[0])
+}
+
+// push appends x, found at position pos in the input, to the
+// sequence.
+func (t *tableaux) push(x, pos int) {
+	for r := range t.p {
+		row := t.p[r]
+		c := search.UpperBound(len(row), func(c int) int { return cmp.Compare(row[c], x) })
+		if c == len(row) {
+			t.p[r] = append(row, x)
+			t.q[r] = append(t.q[r], pos)
+			return
+		}
+		x, row[c] = row[c], x
+	}
+	t.p = append(t.p, []int{x})
+	t.q = append(t.q, []int{pos})
+}
+
+// popFirst removes the first element of the sequence, which must not
+// be empty.
+func (t *tableaux) popFirst() {
+	// The first element's cell in q is the top left corner, since it
+	// holds the smallest position. Slide the hole it leaves to the
+	// outside of the shape, each time moving the smaller of its right
+	// and lower neighbours into it.
+	p, q := t.p, t.q
+	r, c := 0, 0
+	for {
+		right := c+1 < len(q[r])
+		below := r+1 < len(q) && c < len(q[r+1])
+		if right && (!below || q[r][c+1] < q[r+1][c]) {
+			q[r][c] = q[r][c+1]
+			c++
+		} else if below {
+			q[r][c] = q[r+1][c]
+			r++
+		} else {
+			break
+		}
+	}
+
+	// (r, c) is the vacated cell, at the end of its row and the
+	// bottom of its column. Remove it from both tableaux, and run
+	// column insertion backwards from it: in each column to the left,
+	// the element bumped into the next column was the largest one
+	// less than the element it bumped.
+	x := p[r][c]
+	q[r], p[r] = q[r][:c], p[r][:c]
+	if c == 0 {
+		t.p, t.q = p[:r], q[:r]
+	}
+	for k := c - 1; k >= 0; k-- {
+		below := search.UpperBound(len(p), func(rr int) int {
+			if k >= len(p[rr]) {
+				return 1
+			}
+			return cmp.Compare(p[rr][k], x)
+		})
+		x, p[below-1][k] = p[below-1][k], x
+	}
+}
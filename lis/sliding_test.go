@@ -0,0 +1,143 @@
+package lis
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestSlidingLen(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   []int
+		s    Strictness
+		w    int
+		want []int
+	}{
+		{
+			name: "nil",
+			w:    1,
+		},
+		{
+			name: "zero",
+			in:   []int{1, 2, 3},
+			w:    0,
+		},
+		{
+			name: "too_wide",
+			in:   []int{1, 2, 3},
+			w:    4,
+		},
+		{
+			name: "one",
+			in:   []int{3, 1, 2},
+			w:    1,
+			want: []int{1, 1, 1},
+		},
+		{
+			name: "whole",
+			in:   []int{3, 1, 2},
+			w:    3,
+			want: []int{2},
+		},
+		{
+			name: "sorted",
+			in:   []int{1, 2, 3, 4, 5},
+			w:    3,
+			want: []int{3, 3, 3},
+		},
+		{
+			name: "reversed",
+			in:   []int{5, 4, 3, 2, 1},
+			w:    3,
+			want: []int{1, 1, 1},
+		},
+		{
+			name: "mixed",
+			in:   []int{4, 1, 5, 2, 6, 3, 0, 1},
+			w:    4,
+			want: []int{2, 3, 2, 2, 2},
+		},
+		{
+			name: "equal",
+			in:   []int{2, 2, 2, 1, 1},
+			w:    3,
+			want: []int{3, 2, 2},
+		},
+		{
+			name: "equal_strict",
+			in:   []int{2, 2, 2, 1, 1},
+			s:    Strict,
+			w:    3,
+			want: []int{1, 1, 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SlidingLen(tc.in, cmp.Compare, tc.s, tc.w)
+			if diff := diff.Diff(got, tc.want); diff != "" {
+				t.Errorf("SlidingLen(%v, %d) is wrong (-got+want):\n%s", tc.in, tc.w, diff)
+			}
+		})
+	}
+}
+
+func TestSlidingLenRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 100
+	const numIters = 200
+
+	for range numIters {
+		input := randomInts(rand.Intn(numVals) + 1)
+		// Draw from a small range of values sometimes, to exercise
+		// equal elements.
+		if rand.Intn(2) == 0 {
+			for i := range input {
+				input[i] %= 5
+			}
+		}
+		w := rand.Intn(len(input)) + 1
+		for _, s := range []Strictness{NonStrict, Strict} {
+			want := make([]int, len(input)-w+1)
+			for i := range want {
+				want[i] = LongestLen(input[i:i+w], cmp.Compare, s)
+			}
+			got := SlidingLen(input, cmp.Compare, s, w)
+			if diff := diff.Diff(got, want); diff != "" {
+				t.Logf("Input: %v, w=%d, strictness %v", input, w, s)
+				t.Errorf("SlidingLen differs from LongestLen per window (-got+want):\n%s", diff)
+			}
+		}
+	}
+}
+
+func BenchmarkSlidingLen(b *testing.B) {
+	const n = 100_000
+	r := rand.New(rand.NewSource(1))
+	random := make([]int, n)
+	for i := range random {
+		random[i] = r.Intn(n)
+	}
+
+	for _, w := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("SlidingLen/w=%d", w), func(b *testing.B) {
+			for range b.N {
+				SlidingLen(random, cmp.Compare, NonStrict, w)
+			}
+		})
+		b.Run(fmt.Sprintf("LongestLen/w=%d", w), func(b *testing.B) {
+			for range b.N {
+				for i := 0; i+w <= n; i++ {
+					LongestLen(random[i:i+w], cmp.Compare, NonStrict)
+				}
+			}
+		})
+	}
+}
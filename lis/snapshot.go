@@ -0,0 +1,47 @@
+package lis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Snapshot returns a canonical text rendering of the longest
+// increasing subsequence of lst, suitable for golden files and for
+// reviewing changes in results with an ordinary text diff.
+//
+// The rendering has one line per element of lst, in input order. Each
+// line is "+" for an element of the subsequence or "-" for a removed
+// element, followed by the element's index and its value. For example:
+//
+//	Snapshot([]int{1, 5, 2}, cmp.Compare, nil) ==
+//	`+ 0 1
+//	- 1 5
+//	+ 2 2
+//	`
+//
+// Values are formatted with str, or with fmt.Sprint if str is nil, so
+// types implementing fmt.Stringer render using their String method.
+// Values whose rendering contains a line break are quoted with
+// strconv.Quote, so that every element stays on a single line.
+func Snapshot[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, str func(T) string) string {
+	if str == nil {
+		str = func(v T) string { return fmt.Sprint(v) }
+	}
+
+	sorted, _ := LISIndices(lst, cmp)
+	var b strings.Builder
+	for i, v := range lst {
+		status := "-"
+		if len(sorted) > 0 && sorted[0] == i {
+			status = "+"
+			sorted = sorted[1:]
+		}
+		s := str(v)
+		if strings.ContainsAny(s, "\r\n") {
+			s = strconv.Quote(s)
+		}
+		fmt.Fprintf(&b, "%s %d %s\n", status, i, s)
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package lis
+
+import (
+	"cmp"
+	"strings"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   []string
+		str  func(string) string
+		want string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "simple",
+			in:   []string{"a", "c", "b"},
+			want: `
++ 0 a
+- 1 c
++ 2 b
+`,
+		},
+		{
+			name: "custom_format",
+			in:   []string{"b", "a"},
+			str:  strings.ToUpper,
+			want: `
+- 0 B
++ 1 A
+`,
+		},
+		{
+			name: "line_breaks",
+			in:   []string{"a\nb", "c"},
+			want: `
++ 0 "a\nb"
++ 1 c
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Snapshot(tc.in, cmp.Compare, tc.str)
+			want := strings.TrimPrefix(tc.want, "\n")
+			if diff := diff.Diff(got, want); diff != "" {
+				t.Errorf("Snapshot is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
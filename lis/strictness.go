@@ -0,0 +1,51 @@
+package lis
+
+// Strictness selects whether a subsequence may contain elements that
+// compare equal.
+type Strictness int
+
+const (
+	// NonStrict allows consecutive elements of the subsequence to
+	// compare equal: the subsequence is non-decreasing. This is what
+	// LIS computes.
+	NonStrict Strictness = iota
+	// Strict requires each element of the subsequence to compare
+	// greater than the one before it: the subsequence is strictly
+	// increasing.
+	Strict
+)
+
+// Longest computes a longest increasing subsequence of lst, whose
+// elements must be totally ordered by cmp, with the given
+// strictness. It is the engine behind LIS, LDS and LNIS, which are
+// Longest with a fixed strictness, and a reversed cmp for the
+// decreasing variants.
+//
+// Outputs and tie-breaking are as for LIS.
+func Longest[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, s Strictness) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	prev, last, length := longest(lst, cmp, s == Strict)
+	return partition(lst, prev, last, length)
+}
+
+// LongestIndices returns the indices in lst of the subsequence that
+// Longest would compute, in increasing order. Unlike LISIndices, it
+// doesn't also list the indices of the remaining elements, for
+// callers that only need the subsequence.
+func LongestIndices[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, s Strictness) []int {
+	if len(lst) == 0 {
+		return nil
+	}
+
+	prev, last, length := longest(lst, cmp, s == Strict)
+	return indices(prev, last, length)
+}
+
+// LongestLen returns the length of the subsequence that Longest
+// would compute, at the cost of Len.
+func LongestLen[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, s Strictness) int {
+	return length(lst, cmp, s == Strict)
+}
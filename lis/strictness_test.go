@@ -0,0 +1,97 @@
+package lis
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLongest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		s          Strictness
+		wantSorted []int
+		wantRest   []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "non_strict",
+			in:         []int{1, 2, 2, 0, 3},
+			s:          NonStrict,
+			wantSorted: []int{1, 2, 2, 3},
+			wantRest:   []int{0},
+		},
+		{
+			name:       "strict",
+			in:         []int{1, 2, 2, 0, 3},
+			s:          Strict,
+			wantSorted: []int{1, 2, 3},
+			wantRest:   []int{2, 0},
+		},
+		{
+			name:       "strict_all_equal",
+			in:         []int{4, 4, 4},
+			s:          Strict,
+			wantSorted: []int{4},
+			wantRest:   []int{4, 4},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := Longest(tc.in, cmp.Compare, tc.s)
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("Longest subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("Longest remainder is wrong (-got+want):\n%s", diff)
+			}
+			idxs := LongestIndices(tc.in, cmp.Compare, tc.s)
+			if !slices.IsSorted(idxs) {
+				t.Errorf("LongestIndices = %v, not in increasing order", idxs)
+			}
+			var gotIdxSorted []int
+			for _, i := range idxs {
+				gotIdxSorted = append(gotIdxSorted, tc.in[i])
+			}
+			if diff := diff.Diff(gotIdxSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LongestIndices subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if got, want := LongestLen(tc.in, cmp.Compare, tc.s), len(tc.wantSorted); got != want {
+				t.Errorf("LongestLen = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestLongestRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 100
+
+	for range numIters {
+		input := randomInts(numVals)
+		for i := range input {
+			input[i] %= 10
+		}
+
+		sorted, rest := Longest(input, cmp.Compare, Strict)
+		checkPartition(t, input, sorted, rest)
+		for i := 1; i < len(sorted); i++ {
+			if sorted[i] <= sorted[i-1] {
+				t.Fatalf("Longest(%v, Strict) = %v, not strictly increasing", input, sorted)
+			}
+		}
+		if got, want := len(sorted), quadraticStrictLen(input); got != want {
+			t.Errorf("len(Longest(%v, Strict)) = %d, want %d", input, got, want)
+		}
+	}
+}
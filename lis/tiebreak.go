@@ -0,0 +1,71 @@
+package lis
+
+import "github.com/danderson/go-lnds/search"
+
+// LISLargest is like LIS, but if several longest increasing
+// subsequences exist, it returns the one whose values are
+// lexicographically greatest according to cmp, instead of the
+// smallest.
+//
+// That subsequence is also the one whose indices in lst are
+// lexicographically smallest, i.e. the one that keeps the earliest
+// possible elements. LISLargest guarantees both properties.
+func LISLargest[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	keep, length := greatest(lst, cmp)
+	return partitionMask(lst, keep, length)
+}
+
+// greatest returns the mask of a longest increasing subsequence of lst
+// with lexicographically greatest values, as for longestOf.
+func greatest[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (keep []bool, length int) {
+	// Group k lists, in input order, every element that starts a
+	// longest subsequence of length k+1 beginning at that element.
+	// This is the mirror image of the piles used by weightedLongest,
+	// built by running the same dealing process backwards over lst.
+	// Group k is elems[start[k]:start[k+1]].
+	//
+	// Within a group, values are strictly decreasing: if an element
+	// was less than or equal to a later element of the same group, it
+	// could be prepended to that element's subsequence, and would be
+	// in a higher group.
+	var (
+		level = make([]int, len(lst))
+		tails = make([]int, 0, len(lst)) // index of the first element of group k
+	)
+	for i := len(lst) - 1; i >= 0; i-- {
+		k := upperBoundFast(len(tails), func(k int) int {
+			return cmp(lst[i], lst[tails[k]])
+		})
+		if k == len(tails) {
+			tails = append(tails, i)
+		}
+		tails[k] = i
+		level[i] = k
+	}
+	start, elems := groupByLevel(level, len(tails))
+
+	// Now build the subsequence greedily from the front. The k-th
+	// element must come from group length-k, after the previous
+	// element and not less than it. Because the group's values
+	// decrease in input order, the first element of the group after
+	// the previous element is the greatest available, and it must be
+	// valid since some valid element exists. Being the first such
+	// element, it's also the earliest available, so the same choice
+	// yields the lexicographically smallest indices.
+	length = len(tails)
+	keep = make([]bool, len(lst))
+	prevIdx := -1
+	for k := length - 1; k >= 0; k-- {
+		g := elems[start[k]:start[k+1]]
+		pos := search.UpperBound(len(g), func(p int) int {
+			return g[p] - prevIdx
+		})
+		prevIdx = g[pos]
+		keep[prevIdx] = true
+	}
+	return keep, length
+}
@@ -0,0 +1,167 @@
+package lis
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLISLargest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		wantSorted []int
+		wantRest   []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "singleton",
+			in:         []int{1},
+			wantSorted: []int{1},
+			wantRest:   []int{},
+		},
+		{
+			name:       "backwards",
+			in:         []int{4, 3, 2, 1},
+			wantSorted: []int{4},
+			wantRest:   []int{3, 2, 1},
+		},
+		{
+			name:       "lexicographically_largest",
+			in:         []int{1, 3, 2, 4, 3},
+			wantSorted: []int{1, 3, 4},
+			wantRest:   []int{2, 3},
+		},
+		{
+			name:       "swapped_pairs",
+			in:         []int{2, 1, 4, 3, 6, 5, 8, 7},
+			wantSorted: []int{2, 4, 6, 8},
+			wantRest:   []int{1, 3, 5, 7},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := LISLargest(tc.in, cmp.Compare)
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LISLargest subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LISLargest remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLISLargestRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 12
+	const numIters = 200
+
+	for range numIters {
+		input := randomInts(numVals)
+		gotSorted, gotRest := LISLargest(input, cmp.Compare)
+		checkPartition(t, input, gotSorted, gotRest)
+
+		var want, earliest []int
+		for idxs := range AllLIS(input, cmp.Compare) {
+			if vals := valuesAt(input, idxs); want == nil || slices.Compare(vals, want) > 0 {
+				want = vals
+			}
+			if earliest == nil || slices.Compare(idxs, earliest) < 0 {
+				earliest = idxs
+			}
+		}
+		if diff := diff.Diff(gotSorted, want); diff != "" {
+			t.Logf("Input: %v", input)
+			t.Errorf("LISLargest subsequence is wrong (-got+want):\n%s", diff)
+		}
+
+		keep, _ := greatest(input, cmp.Compare)
+		var gotIdxs []int
+		for i, k := range keep {
+			if k {
+				gotIdxs = append(gotIdxs, i)
+			}
+		}
+		if diff := diff.Diff(gotIdxs, earliest); diff != "" {
+			t.Logf("Input: %v", input)
+			t.Errorf("LISLargest did not keep the earliest elements (-got+want):\n%s", diff)
+		}
+	}
+}
+
+// valuesAt returns the elements of lst at idxs.
+func valuesAt[T any](lst []T, idxs []int) []T {
+	ret := make([]T, 0, len(idxs))
+	for _, i := range idxs {
+		ret = append(ret, lst[i])
+	}
+	return ret
+}
+
+func TestLISKeepsLatest(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 12
+	const numIters = 200
+
+	for range numIters {
+		input := randomInts(numVals)
+		for i := range input {
+			// Lots of duplicates, so that many subsequences have
+			// equal values.
+			input[i] %= 4
+		}
+
+		var latest []int
+		for idxs := range AllLIS(input, cmp.Compare) {
+			if latest == nil || slices.Compare(idxs, latest) > 0 {
+				latest = idxs
+			}
+		}
+		got, _ := LISIndices(input, cmp.Compare)
+		if diff := diff.Diff(got, latest); diff != "" {
+			t.Logf("Input: %v", input)
+			t.Errorf("LIS did not keep the latest elements (-got+want):\n%s", diff)
+		}
+	}
+}
+
+func TestLISKeepsSmallest(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 12
+	const numIters = 200
+
+	for range numIters {
+		input := randomInts(numVals)
+		for i := range input {
+			input[i] %= 8
+		}
+
+		sorted, _ := LIS(input, cmp.Compare)
+		minSum := -1
+		for idxs := range AllLIS(input, cmp.Compare) {
+			other := valuesAt(input, idxs)
+			for k := range other {
+				if other[k] < sorted[k] {
+					t.Fatalf("LIS(%v) = %v, but %v has a smaller element at position %d", input, sorted, other, k)
+				}
+			}
+			if s := sum(other); minSum < 0 || s < minSum {
+				minSum = s
+			}
+		}
+		if got := sum(sorted); got != minSum {
+			t.Errorf("LIS(%v) = %v, sum %d, want minimal sum %d", input, sorted, got, minSum)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package lis
+
+// prefixBest is a Fenwick tree over positions [0, n), each of which
+// holds a set of indices. It answers "what is the best index at any
+// position before p" in O(log n), where better(i, j) reports whether
+// index i is better than index j.
+type prefixBest struct {
+	// tree is 1-based. Each node holds the best index stored in its
+	// range, or -1 if none.
+	tree   []int
+	better func(i, j int) bool
+}
+
+func newPrefixBest(n int, better func(i, j int) bool) prefixBest {
+	tree := make([]int, n+1)
+	for k := range tree {
+		tree[k] = -1
+	}
+	return prefixBest{tree, better}
+}
+
+// add stores index i at position pos.
+func (t prefixBest) add(pos, i int) {
+	for k := pos + 1; k < len(t.tree); k += k & -k {
+		if j := t.tree[k]; j < 0 || t.better(i, j) {
+			t.tree[k] = i
+		}
+	}
+}
+
+// best returns the best index stored at positions [0, p), or -1 if
+// there are none.
+func (t prefixBest) best(p int) int {
+	ret := -1
+	for k := p; k > 0; k -= k & -k {
+		if j := t.tree[k]; j >= 0 && (ret < 0 || t.better(j, ret)) {
+			ret = j
+		}
+	}
+	return ret
+}
+
+// rangeBest is like prefixBest, but answers "what is the best index
+// at any position in [lo, hi)", and allows indices to be removed. It
+// is a segment tree, so it is a little slower and twice the size of a
+// prefixBest.
+//
+// Unlike prefixBest, each position holds at most one index.
+type rangeBest struct {
+	// tree[n+pos] holds the best index at position pos, and each
+	// tree[k] with k < n holds the best of tree[2k] and tree[2k+1].
+	// Entries are -1 if there are no indices in their range.
+	tree   []int
+	n      int
+	better func(i, j int) bool
+}
+
+func newRangeBest(n int, better func(i, j int) bool) rangeBest {
+	tree := make([]int, 2*n)
+	for k := range tree {
+		tree[k] = -1
+	}
+	return rangeBest{tree, n, better}
+}
+
+// add stores index i at position pos, unless pos already holds a
+// better index.
+func (t rangeBest) add(pos, i int) {
+	for k := t.n + pos; k > 0; k /= 2 {
+		if j := t.tree[k]; j >= 0 && !t.better(i, j) {
+			return
+		}
+		t.tree[k] = i
+	}
+}
+
+// best returns the best index stored at positions [lo, hi), or -1 if
+// there are none.
+func (t rangeBest) best(lo, hi int) int {
+	ret := -1
+	pick := func(j int) {
+		if j >= 0 && (ret < 0 || t.better(j, ret)) {
+			ret = j
+		}
+	}
+	for lo, hi = lo+t.n, hi+t.n; lo < hi; lo, hi = lo/2, hi/2 {
+		if lo&1 == 1 {
+			pick(t.tree[lo])
+			lo++
+		}
+		if hi&1 == 1 {
+			hi--
+			pick(t.tree[hi])
+		}
+	}
+	return ret
+}
+
+// clear removes the index stored at position pos.
+func (t rangeBest) clear(pos int) {
+	k := t.n + pos
+	t.tree[k] = -1
+	for k /= 2; k > 0; k /= 2 {
+		l, r := t.tree[2*k], t.tree[2*k+1]
+		if l < 0 || (r >= 0 && t.better(r, l)) {
+			l = r
+		}
+		t.tree[k] = l
+	}
+}
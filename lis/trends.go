@@ -0,0 +1,24 @@
+package lis
+
+// Trends returns the indices of a longest increasing subsequence of
+// lst, and the indices of a longest strictly decreasing subsequence
+// of lst. The elements of lst must be totally ordered by cmp.
+//
+// increasing is the dominant trend of the input, the same
+// subsequence that LIS returns. decreasing is the dominant violation
+// of that trend: the longest chain of elements that are all out of
+// order with respect to each other. Its length is also the minimum
+// number of increasing subsequences needed to cover lst.
+func Trends[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (increasing, decreasing []int) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	prev, last, length := longest(lst, cmp, false)
+	increasing = indices(prev, last, length)
+
+	prev, last, length = longest(lst, reverse(cmp), true)
+	decreasing = indices(prev, last, length)
+
+	return increasing, decreasing
+}
@@ -0,0 +1,122 @@
+package lis
+
+import (
+	"cmp"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestTrends(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      []int
+		wantInc []int
+		wantDec []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:    "singleton",
+			in:      []int{1},
+			wantInc: []int{0},
+			wantDec: []int{0},
+		},
+		{
+			name:    "sorted",
+			in:      []int{1, 2, 3},
+			wantInc: []int{0, 1, 2},
+			wantDec: []int{2},
+		},
+		{
+			name:    "backwards",
+			in:      []int{3, 2, 1},
+			wantInc: []int{2},
+			wantDec: []int{0, 1, 2},
+		},
+		{
+			name:    "equal",
+			in:      []int{2, 2, 2},
+			wantInc: []int{0, 1, 2},
+			wantDec: []int{2},
+		},
+		{
+			name:    "organ_pipe",
+			in:      []int{1, 2, 3, 4, 3, 2, 1},
+			wantInc: []int{0, 1, 2, 4},
+			wantDec: []int{3, 4, 5, 6},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotInc, gotDec := Trends(tc.in, cmp.Compare)
+			if diff := diff.Diff(gotInc, tc.wantInc); diff != "" {
+				t.Errorf("Trends increasing is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotDec, tc.wantDec); diff != "" {
+				t.Errorf("Trends decreasing is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTrendsRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 100
+
+	for range numIters {
+		input := randomInts(numVals)
+		inc, dec := Trends(input, cmp.Compare)
+
+		wantInc, _ := LIS(input, cmp.Compare)
+		if got, want := len(inc), len(wantInc); got != want {
+			t.Errorf("len(increasing) = %d, want %d", got, want)
+		}
+		for i := 1; i < len(inc); i++ {
+			if inc[i-1] >= inc[i] || input[inc[i-1]] > input[inc[i]] {
+				t.Errorf("increasing %v is not an increasing subsequence of %v", inc, input)
+				break
+			}
+		}
+		for i := 1; i < len(dec); i++ {
+			if dec[i-1] >= dec[i] || input[dec[i-1]] <= input[dec[i]] {
+				t.Errorf("decreasing %v is not a strictly decreasing subsequence of %v", dec, input)
+				break
+			}
+		}
+
+		// A strictly decreasing subsequence of input is a strictly
+		// increasing subsequence of its negation.
+		neg := make([]int, len(input))
+		for i, v := range input {
+			neg[i] = -v
+		}
+		if got, want := len(dec), quadraticStrictLen(neg); got != want {
+			t.Errorf("len(decreasing) = %d, want %d", got, want)
+		}
+	}
+}
+
+// quadraticStrictLen returns the length of the longest strictly
+// increasing subsequence of lst, using the textbook O(n^2) dynamic
+// programming algorithm.
+func quadraticStrictLen[T cmp.Ordered](lst []T) int {
+	ret := 0
+	lens := make([]int, len(lst))
+	for i := range lst {
+		lens[i] = 1
+		for j := range i {
+			if lst[j] < lst[i] {
+				lens[i] = max(lens[i], lens[j]+1)
+			}
+		}
+		ret = max(ret, lens[i])
+	}
+	return ret
+}
@@ -0,0 +1,101 @@
+package lis
+
+import "github.com/danderson/go-lnds/search"
+
+// Number is a constraint that permits any numeric type that can be
+// summed.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// LISPriority is like LIS, but when several longest increasing
+// subsequences exist, it returns one with the greatest total
+// priority of its elements. priority(i) is the priority of lst[i],
+// and is called once for each index of lst.
+//
+// Priorities only break ties between subsequences of equal length: a
+// longer subsequence is always preferred, regardless of priority.
+func LISPriority[T any, W Number, Slice ~[]T](lst Slice, cmp func(T, T) int, priority func(i int) W) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	prev, last, length := weightedLongest(lst, cmp, priority, func(a, b W) bool { return a > b })
+	return partition(lst, prev, last, length)
+}
+
+// weightedLongest is like longest, but among all longest
+// subsequences, returns the one whose total weight is best, where
+// better(a, b) reports whether weight a is better than weight b.
+func weightedLongest[T any, W Number, Slice ~[]T](lst Slice, cmp func(T, T) int, weight func(i int) W, better func(a, b W) bool) (prev []int, last, length int) {
+	// This uses the same patience structure as longest, except that
+	// instead of remembering just the final element of one
+	// subsequence of each length, it remembers all of them. Pile L
+	// lists, in input order, every element that ends a longest
+	// subsequence of length L+1 ending at that element.
+	//
+	// Each new element joins the pile longest would have put it in,
+	// and within a pile, values are strictly decreasing (a later
+	// element that didn't compare less than the pile's previous
+	// element would have joined a later pile). So the possible
+	// predecessors for a new element of value x, among the elements
+	// of the previous pile, are exactly those with value <= x, which
+	// is a suffix of that pile.
+	//
+	// Among those, we want the one heading the best-weighted
+	// subsequence, i.e. a maximum over a suffix of an append-only
+	// list. Each pile keeps a monotonic stack of suffix maxima to
+	// answer that in O(log n): stack entries are positions in the
+	// pile with strictly worsening total weights, and the best
+	// weight over pile[p:] is at the first stack entry >= p.
+	//
+	// The piles are dealt up front, and laid out in one shared array
+	// by groupByLevel. The main loop then replays the input in order,
+	// with size[k] counting the elements of pile k seen so far. A
+	// pile's stack is never longer than the pile itself, so the
+	// stacks share a second array with the same layout: pile k's
+	// stack is stacks[start[k]:start[k]+depth[k]].
+	level, numPiles := dealLevels(lst, cmp)
+	start, elems := groupByLevel(level, numPiles)
+	var (
+		size   = make([]int, numPiles)
+		depth  = make([]int, numPiles)
+		stacks = make([]int, len(lst))
+		totals = make([]W, len(lst))
+	)
+	prev = make([]int, len(lst))
+
+	for i := range lst {
+		k := level[i]
+		prev[i] = -1
+		totals[i] = weight(i)
+		if k > 0 {
+			pile := elems[start[k-1] : start[k-1]+size[k-1]]
+			stack := stacks[start[k-1] : start[k-1]+depth[k-1]]
+			first := search.LowerBound(len(pile), func(pos int) int {
+				if cmp(lst[pile[pos]], lst[i]) > 0 {
+					return -1
+				}
+				return 0
+			})
+			best := stack[search.LowerBound(len(stack), func(s int) int {
+				return stack[s] - first
+			})]
+			prev[i] = pile[best]
+			totals[i] += totals[prev[i]]
+		}
+
+		pile, stack := elems[start[k]:], stacks[start[k]:]
+		for depth[k] > 0 && !better(totals[pile[stack[depth[k]-1]]], totals[i]) {
+			depth[k]--
+		}
+		stack[depth[k]] = size[k]
+		depth[k]++
+		size[k]++
+	}
+
+	top := start[numPiles-1]
+	return prev, elems[top+stacks[top]], numPiles
+}
@@ -0,0 +1,133 @@
+package lis
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLISPriority(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		prio       []int
+		wantSorted []int
+		wantRest   []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "no_ties",
+			in:         []int{1, 2, 0, 3},
+			prio:       []int{0, 0, 100, 0},
+			wantSorted: []int{1, 2, 3},
+			wantRest:   []int{0},
+		},
+		{
+			name:       "prefer_flagged",
+			in:         []int{2, 1, 4, 3},
+			prio:       []int{1, 0, 0, 1},
+			wantSorted: []int{2, 3},
+			wantRest:   []int{1, 4},
+		},
+		{
+			name:       "prefer_flagged_other_way",
+			in:         []int{2, 1, 4, 3},
+			prio:       []int{0, 1, 1, 0},
+			wantSorted: []int{1, 4},
+			wantRest:   []int{2, 3},
+		},
+		{
+			name:       "sum_beats_single",
+			in:         []int{5, 1, 2, 6, 3, 4},
+			prio:       []int{0, 1, 1, 0, 1, 1},
+			wantSorted: []int{1, 2, 3, 4},
+			wantRest:   []int{5, 6},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := LISPriority(tc.in, cmp.Compare, func(i int) int { return tc.prio[i] })
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LISPriority subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LISPriority remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLISPriorityRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 12
+	const numIters = 200
+
+	for range numIters {
+		input := randomInts(numVals)
+		prio := make([]int, len(input))
+		for i := range prio {
+			prio[i] = rand.Intn(5)
+		}
+
+		gotSorted, gotRest := LISPriority(input, cmp.Compare, func(i int) int { return prio[i] })
+		checkPartition(t, input, gotSorted, gotRest)
+		if !slices.IsSorted(gotSorted) {
+			t.Fatalf("LISPriority(%v) = %v, not sorted", input, gotSorted)
+		}
+		if got, want := len(gotSorted), quadraticLen(input); got != want {
+			t.Fatalf("len(LISPriority(%v)) = %d, want %d", input, got, want)
+		}
+
+		// Values can repeat with different priorities, so the
+		// total has to be computed from the chosen indices rather
+		// than from gotSorted.
+		wantPrio := bruteForceMaxPriority(input, prio)
+		gotPrio := priorityOf(input, prio, cmp.Compare, func(i int) int { return prio[i] })
+		if gotPrio != wantPrio {
+			t.Errorf("LISPriority(%v, prio=%v) has total priority %d, want %d", input, prio, gotPrio, wantPrio)
+		}
+	}
+}
+
+// priorityOf returns the total priority of the subsequence that
+// LISPriority picks.
+func priorityOf(lst, prio []int, cmp func(int, int) int, priority func(int) int) int {
+	prev, last, _ := weightedLongest(lst, cmp, priority, func(a, b int) bool { return a > b })
+	total := 0
+	for i := last; i >= 0; i = prev[i] {
+		total += prio[i]
+	}
+	return total
+}
+
+// bruteForceMaxPriority returns the greatest total priority over all
+// longest non-decreasing subsequences of lst.
+func bruteForceMaxPriority(lst, prio []int) int {
+	bestLen, bestPrio := -1, 0
+	for mask := range 1 << len(lst) {
+		var seq []int
+		total := 0
+		for i, v := range lst {
+			if mask&(1<<i) != 0 {
+				seq = append(seq, v)
+				total += prio[i]
+			}
+		}
+		if !slices.IsSorted(seq) {
+			continue
+		}
+		if len(seq) > bestLen || (len(seq) == bestLen && total > bestPrio) {
+			bestLen, bestPrio = len(seq), total
+		}
+	}
+	return bestPrio
+}
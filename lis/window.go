@@ -0,0 +1,77 @@
+package lis
+
+import "slices"
+
+// LISWindow is like LIS, but consecutive elements of the subsequence
+// must be at most w positions apart in lst: if lst[i] and lst[j] are
+// consecutive in the subsequence, then j-i <= w. This models
+// "locally sorted" checks, where each element may only be matched
+// against nearby elements.
+//
+// With w = 1, the subsequence is a run of adjacent elements. If w is
+// less than 1, the subsequence has a single element. A w of len(lst)
+// or more does not constrain the subsequence, and LISWindow keeps
+// the same values as LIS.
+//
+// If several longest subsequences exist, LISWindow prefers smaller
+// values, like LIS.
+//
+// LISWindow runs in O(n·logn) time.
+func LISWindow[T any, Slice ~[]T](lst Slice, cmp func(T, T) int, w int) (sorted, rest Slice) {
+	if len(lst) == 0 {
+		return nil, nil
+	}
+
+	// Give every element its own position in value order, with
+	// equal values ordered by index. The valid predecessors of
+	// lst[i] that come before it in lst are then exactly the
+	// elements at positions before pos[i], which are still within
+	// the window. A segment tree over positions finds the best of
+	// them, and elements are cleared from it as they fall out of the
+	// window.
+	order := make([]int, len(lst))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int { return cmp(lst[i], lst[j]) })
+	pos := make([]int, len(lst))
+	for p, i := range order {
+		pos[i] = p
+	}
+
+	var (
+		lens = make([]int, len(lst))
+		prev = make([]int, len(lst))
+		last = 0
+	)
+	better := func(i, j int) bool {
+		if lens[i] != lens[j] {
+			return lens[i] > lens[j]
+		}
+		if c := cmp(lst[i], lst[j]); c != 0 {
+			return c < 0
+		}
+		return i > j
+	}
+	tree := newRangeBest(len(lst), better)
+	w = max(w, 0)
+	for i := range lst {
+		if old := i - w - 1; old >= 0 {
+			tree.clear(pos[old])
+		}
+
+		prev[i] = -1
+		lens[i] = 1
+		if best := tree.best(0, pos[i]); best >= 0 {
+			prev[i] = best
+			lens[i] = lens[best] + 1
+		}
+
+		tree.add(pos[i], i)
+		if better(i, last) {
+			last = i
+		}
+	}
+
+	return partition(lst, prev, last, lens[last])
+}
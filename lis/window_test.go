@@ -0,0 +1,126 @@
+package lis
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestLISWindow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		w          int
+		wantSorted []int
+		wantRest   []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "zero",
+			in:         []int{3, 1, 2},
+			w:          0,
+			wantSorted: []int{1},
+			wantRest:   []int{3, 2},
+		},
+		{
+			name:       "adjacent_run",
+			in:         []int{1, 2, 0, 1, 2, 3, 0},
+			w:          1,
+			wantSorted: []int{0, 1, 2, 3},
+			wantRest:   []int{1, 2, 0},
+		},
+		{
+			name:       "too_far",
+			in:         []int{1, 9, 8, 7, 2, 3},
+			w:          3,
+			wantSorted: []int{2, 3},
+			wantRest:   []int{1, 9, 8, 7},
+		},
+		{
+			name:       "just_close_enough",
+			in:         []int{1, 9, 8, 2, 3},
+			w:          3,
+			wantSorted: []int{1, 2, 3},
+			wantRest:   []int{9, 8},
+		},
+		{
+			name:       "unbounded",
+			in:         []int{1, 9, 8, 7, 2, 3},
+			w:          6,
+			wantSorted: []int{1, 2, 3},
+			wantRest:   []int{9, 8, 7},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSorted, gotRest := LISWindow(tc.in, cmp.Compare, tc.w)
+			if diff := diff.Diff(gotSorted, tc.wantSorted); diff != "" {
+				t.Errorf("LISWindow subsequence is wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(gotRest, tc.wantRest); diff != "" {
+				t.Errorf("LISWindow remainder is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLISWindowRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 12
+	const numIters = 200
+
+	for range numIters {
+		input := randomInts(numVals)
+		w := rand.Intn(numVals + 2)
+
+		gotSorted, gotRest := LISWindow(input, cmp.Compare, w)
+		checkPartition(t, input, gotSorted, gotRest)
+		if !slices.IsSorted(gotSorted) {
+			t.Fatalf("LISWindow(%v, %d) = %v, not sorted", input, w, gotSorted)
+		}
+		if got, want := len(gotSorted), bruteForceWindowLen(input, w); got != want {
+			t.Errorf("len(LISWindow(%v, %d)) = %d, want %d", input, w, got, want)
+		}
+		if w >= len(input) {
+			wantSorted, _ := LIS(input, cmp.Compare)
+			if diff := diff.Diff(gotSorted, wantSorted); diff != "" {
+				t.Errorf("LISWindow(%v, %d) differs from LIS (-got+want):\n%s", input, w, diff)
+			}
+		}
+	}
+}
+
+// bruteForceWindowLen returns the length of the longest
+// non-decreasing subsequence of lst whose consecutive elements are at
+// most w positions apart.
+func bruteForceWindowLen(lst []int, w int) int {
+	best := 0
+	for mask := range 1 << len(lst) {
+		var idxs []int
+		for i := range lst {
+			if mask&(1<<i) != 0 {
+				idxs = append(idxs, i)
+			}
+		}
+		ok := true
+		for k := 1; k < len(idxs); k++ {
+			if lst[idxs[k]] < lst[idxs[k-1]] || idxs[k]-idxs[k-1] > w {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			best = max(best, len(idxs))
+		}
+	}
+	return best
+}
@@ -0,0 +1,97 @@
+package lndstest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+)
+
+// AlmostSortedInts returns n ints, drawn from r, that can be sorted
+// by removing exactly k of them, and no fewer. That is, the longest
+// non-decreasing subsequence of the result has length exactly n-k.
+//
+// AlmostSortedInts panics unless 0 <= k < n, or k = n = 0.
+func AlmostSortedInts(r *rand.Rand, n, k int) []int {
+	if k < 0 || (k >= n && n > 0) || (n == 0 && k != 0) {
+		panic(fmt.Sprintf("AlmostSortedInts: impossible to have %d violations in %d elements", k, n))
+	}
+
+	// The result is a sorted backbone of n-k elements, plus k
+	// violators of two kinds: huge values, greater than the whole
+	// backbone and placed before its last element, and tiny values,
+	// less than the whole backbone and placed after its first
+	// element. Huge values decrease from left to right, and so do
+	// tiny values.
+	//
+	// An increasing subsequence can then include at most one huge
+	// value, which must be its last element, and so excludes at
+	// least the last backbone element. Likewise it can include at
+	// most one tiny value, which must be its first element, and so
+	// excludes at least the first backbone element. Neither can
+	// make it longer than the backbone, so the backbone is a
+	// longest increasing subsequence.
+	m := n - k
+	backbone := make([]int, m)
+	for i := range backbone {
+		backbone[i] = r.Intn(10 * n)
+	}
+	slices.Sort(backbone)
+
+	// huge[g] and tiny[g] are the number of each kind of violator to
+	// place just before backbone[g], or at the end if g == m.
+	huge := make([]int, m+1)
+	tiny := make([]int, m+1)
+	for range k {
+		if r.Intn(2) == 0 {
+			huge[r.Intn(m)]++
+		} else {
+			tiny[1+r.Intn(m)]++
+		}
+	}
+
+	var (
+		ret      = make([]int, 0, n)
+		nextHuge = 10*n + k
+		nextTiny = -1
+	)
+	for g := range m + 1 {
+		// Shuffle the violators within the gap, they're all equally
+		// valid there.
+		kinds := make([]bool, 0, huge[g]+tiny[g])
+		for range huge[g] {
+			kinds = append(kinds, true)
+		}
+		for range tiny[g] {
+			kinds = append(kinds, false)
+		}
+		r.Shuffle(len(kinds), func(i, j int) { kinds[i], kinds[j] = kinds[j], kinds[i] })
+		for _, isHuge := range kinds {
+			if isHuge {
+				ret = append(ret, nextHuge)
+				nextHuge--
+			} else {
+				ret = append(ret, nextTiny)
+				nextTiny--
+			}
+		}
+		if g < m {
+			ret = append(ret, backbone[g])
+		}
+	}
+	return ret
+}
+
+// QuickAlmostSorted returns a function for testing/quick's
+// Config.Values, that generates arguments for a property function
+// whose only argument is a []int. Each generated slice has between
+// k+1 and k+50 elements, and exactly k violations, as for
+// AlmostSortedInts. For example:
+//
+//	quick.Check(func(lst []int) bool { ... }, &quick.Config{Values: lndstest.QuickAlmostSorted(3)})
+func QuickAlmostSorted(k int) func(args []reflect.Value, r *rand.Rand) {
+	return func(args []reflect.Value, r *rand.Rand) {
+		n := k + 1 + r.Intn(50)
+		args[0] = reflect.ValueOf(AlmostSortedInts(r, n, k))
+	}
+}
@@ -0,0 +1,56 @@
+package lndstest
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/danderson/go-lnds/lis"
+)
+
+func TestAlmostSortedInts(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+	for n := range 20 {
+		for k := range max(n, 1) {
+			for range 20 {
+				got := AlmostSortedInts(r, n, k)
+				if len(got) != n {
+					t.Fatalf("AlmostSortedInts(%d, %d) has length %d", n, k, len(got))
+				}
+				if violations := n - lis.Len(got, cmp.Compare); violations != k {
+					t.Fatalf("AlmostSortedInts(%d, %d) = %v, has %d violations", n, k, got, violations)
+				}
+			}
+		}
+	}
+}
+
+func TestAlmostSortedIntsPanics(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct{ n, k int }{{0, 1}, {3, 3}, {3, -1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("AlmostSortedInts(%d, %d) did not panic", tc.n, tc.k)
+				}
+			}()
+			AlmostSortedInts(rand.New(rand.NewSource(1)), tc.n, tc.k)
+		}()
+	}
+}
+
+func TestQuickAlmostSorted(t *testing.T) {
+	t.Parallel()
+
+	const k = 3
+	prop := func(lst []int) bool {
+		return len(lst)-lis.Len(lst, cmp.Compare) == k
+	}
+	if err := quick.Check(prop, &quick.Config{Values: QuickAlmostSorted(k)}); err != nil {
+		t.Error(err)
+	}
+}
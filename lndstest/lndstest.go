@@ -0,0 +1,82 @@
+// Package lndstest provides test assertions about the ordering of
+// lists.
+//
+// When an assertion fails, it reports the smallest set of elements
+// that would have to be removed for the list to be in order, as
+// computed by lis.LIS. For a list that is almost sorted, those are
+// usually the elements the test author needs to look at, rather than
+// the first pair that happens to be out of order.
+//
+// AuditOrder and RequireRowsOrdered apply the same checks to query
+// results read through a RowSource, such as *sql.Rows, to verify
+// ORDER BY guarantees in integration tests.
+//
+// The package also generates almost sorted inputs with a known amount
+// of disorder, for property tests of code that must tolerate it.
+package lndstest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danderson/go-lnds/lis"
+)
+
+// maxReported is the number of violating elements that a failure
+// message lists before summarizing the rest.
+const maxReported = 10
+
+// RequireSortedWithin fails the test immediately unless lst can be
+// sorted by removing at most k elements, i.e. unless its longest
+// non-decreasing subsequence according to cmp is at least
+// len(lst)-k long. With k = 0, it requires lst to be sorted.
+//
+// On failure, it reports a smallest set of elements whose removal
+// would leave lst sorted.
+func RequireSortedWithin[T any, Slice ~[]T](t testing.TB, lst Slice, cmp func(T, T) int, k int) {
+	t.Helper()
+
+	_, rest := lis.LISIndices(lst, cmp)
+	if len(rest) <= k {
+		return
+	}
+	t.Fatalf("list of %d elements has %d out of order, want at most %d:\n%s", len(lst), len(rest), k, describe(lst, rest))
+}
+
+// A Timestamped value has a point in time, such as an event in a log.
+type Timestamped interface {
+	Timestamp() time.Time
+}
+
+// RequireMonotoneTimestamps fails the test immediately unless the
+// timestamps of events never go backwards. Equal timestamps are
+// allowed.
+//
+// On failure, it reports a smallest set of events whose removal
+// would leave the timestamps monotone.
+func RequireMonotoneTimestamps[E Timestamped, Slice ~[]E](t testing.TB, events Slice) {
+	t.Helper()
+
+	_, rest := lis.LISIndices(events, func(a, b E) int {
+		return a.Timestamp().Compare(b.Timestamp())
+	})
+	if len(rest) == 0 {
+		return
+	}
+	t.Fatalf("%d of %d events have out of order timestamps:\n%s", len(rest), len(events), describe(events, rest))
+}
+
+// describe lists the elements of lst at idxs, one per line.
+func describe[T any, Slice ~[]T](lst Slice, idxs []int) string {
+	var b strings.Builder
+	for n, i := range idxs {
+		if n == maxReported {
+			fmt.Fprintf(&b, "  ... and %d more\n", len(idxs)-n)
+			break
+		}
+		fmt.Fprintf(&b, "  [%d] %v\n", i, lst[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,144 @@
+package lndstest
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeT records failures instead of failing the test.
+type fakeT struct {
+	testing.TB
+	failures []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func TestRequireSortedWithin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		in       []int
+		k        int
+		wantFail bool
+		wantMsg  []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "sorted",
+			in:   []int{1, 2, 2, 3},
+		},
+		{
+			name: "within",
+			in:   []int{1, 2, 9, 3, 4},
+			k:    1,
+		},
+		{
+			name:     "not_sorted",
+			in:       []int{1, 2, 9, 3, 4},
+			wantFail: true,
+			wantMsg:  []string{"has 1 out of order, want at most 0", "[2] 9"},
+		},
+		{
+			name:     "too_many",
+			in:       []int{5, 1, 6, 2, 3},
+			k:        1,
+			wantFail: true,
+			wantMsg:  []string{"has 2 out of order", "[0] 5", "[2] 6"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &fakeT{}
+			RequireSortedWithin(f, tc.in, cmp.Compare, tc.k)
+			checkFailures(t, f, tc.wantFail, tc.wantMsg)
+		})
+	}
+}
+
+func TestRequireSortedWithinTruncates(t *testing.T) {
+	t.Parallel()
+
+	in := make([]int, 30)
+	for i := range in {
+		in[i] = len(in) - i
+	}
+	f := &fakeT{}
+	RequireSortedWithin(f, in, cmp.Compare, 0)
+	checkFailures(t, f, true, []string{"has 29 out of order", "... and 19 more"})
+}
+
+type event struct {
+	name string
+	at   time.Time
+}
+
+func (e event) Timestamp() time.Time { return e.at }
+
+func (e event) String() string { return e.name }
+
+func TestRequireMonotoneTimestamps(t *testing.T) {
+	t.Parallel()
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(name string, sec int) event {
+		return event{name, t0.Add(time.Duration(sec) * time.Second)}
+	}
+
+	tests := []struct {
+		name     string
+		in       []event
+		wantFail bool
+		wantMsg  []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "monotone",
+			in:   []event{at("a", 1), at("b", 1), at("c", 2)},
+		},
+		{
+			name:     "late",
+			in:       []event{at("a", 1), at("b", 2), at("late", 0), at("c", 3)},
+			wantFail: true,
+			wantMsg:  []string{"1 of 4 events", "[2] late"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &fakeT{}
+			RequireMonotoneTimestamps(f, tc.in)
+			checkFailures(t, f, tc.wantFail, tc.wantMsg)
+		})
+	}
+}
+
+func checkFailures(t *testing.T, f *fakeT, wantFail bool, wantMsg []string) {
+	t.Helper()
+	if !wantFail {
+		if len(f.failures) != 0 {
+			t.Fatalf("unexpected failure: %s", f.failures)
+		}
+		return
+	}
+	if len(f.failures) != 1 {
+		t.Fatalf("got %d failures, want 1", len(f.failures))
+	}
+	for _, want := range wantMsg {
+		if !strings.Contains(f.failures[0], want) {
+			t.Errorf("failure message does not contain %q:\n%s", want, f.failures[0])
+		}
+	}
+}
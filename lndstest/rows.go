@@ -0,0 +1,119 @@
+package lndstest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/danderson/go-lnds/lis"
+)
+
+// A RowSource iterates over the rows of a query result. *sql.Rows
+// implements it.
+type RowSource interface {
+	// Next prepares the next row for Scan, and reports whether there
+	// is one.
+	Next() bool
+	// Scan copies the columns of the current row into dest.
+	Scan(dest ...any) error
+	// Err returns the error, if any, that ended the iteration.
+	Err() error
+}
+
+// An OrderReport describes how well the rows of a query result follow
+// the order they were expected to have, such as that of the query's
+// ORDER BY clause.
+type OrderReport[K any] struct {
+	// Rows is the number of rows read.
+	Rows int
+	// NumViolations is the number of rows whose key sorts before the
+	// key of the row just before them.
+	NumViolations int
+	// Violations lists the first of those rows, in the order they
+	// were read.
+	Violations []Violation[K]
+	// MinRemovals is the smallest number of rows that would have to
+	// be removed for the rest to be in order. It is 0 if and only if
+	// there are no violations.
+	MinRemovals int
+}
+
+// A Violation is a row whose key sorts before the key of the row just
+// before it.
+type Violation[K any] struct {
+	// Row is the index of the row in the result, counting from 0.
+	Row int
+	// Key is the row's key, and Prev the key of the row before it.
+	Key, Prev K
+}
+
+// AuditOrder reads every row of rows, extracting a key from each with
+// scan, and reports whether the keys are in non-decreasing order
+// according to cmp. The report lists at most maxViolations
+// violations, but MinRemovals accounts for all of them.
+//
+// AuditOrder keeps every key until it returns. opts configure the
+// lis.Online that holds them, and lis.WithMaxLen bounds how many rows
+// it reads: if rows has more, AuditOrder returns an error wrapping a
+// *lis.TooLongError.
+//
+// AuditOrder returns an error if scan or rows.Err do. It does not
+// close rows.
+func AuditOrder[K any](rows RowSource, scan func(RowSource) (K, error), cmp func(K, K) int, maxViolations int, opts ...lis.Option) (OrderReport[K], error) {
+	var (
+		ret    OrderReport[K]
+		keys   = lis.NewOnline(cmp, opts...)
+		prev   K
+		numRow int
+	)
+	for ; rows.Next(); numRow++ {
+		key, err := scan(rows)
+		if err != nil {
+			return OrderReport[K]{}, fmt.Errorf("scanning row %d: %w", numRow, err)
+		}
+		if err := keys.Push(key); err != nil {
+			return OrderReport[K]{}, fmt.Errorf("reading row %d: %w", numRow, err)
+		}
+		if numRow > 0 && cmp(key, prev) < 0 {
+			ret.NumViolations++
+			if len(ret.Violations) < maxViolations {
+				ret.Violations = append(ret.Violations, Violation[K]{Row: numRow, Key: key, Prev: prev})
+			}
+		}
+		prev = key
+	}
+	if err := rows.Err(); err != nil {
+		return OrderReport[K]{}, err
+	}
+
+	ret.Rows = numRow
+	ret.MinRemovals = numRow - keys.Len()
+	return ret, nil
+}
+
+// RequireRowsOrdered fails the test immediately unless the keys that
+// scan extracts from rows are in non-decreasing order according to
+// cmp, or if reading the rows fails.
+//
+// On failure, it reports the first violations, and how many rows
+// would have to be removed for the rest to be in order.
+func RequireRowsOrdered[K any](t testing.TB, rows RowSource, scan func(RowSource) (K, error), cmp func(K, K) int) {
+	t.Helper()
+
+	report, err := AuditOrder(rows, scan, cmp, maxReported)
+	if err != nil {
+		t.Fatalf("reading rows: %v", err)
+	}
+	if report.MinRemovals == 0 {
+		return
+	}
+
+	var b strings.Builder
+	for _, v := range report.Violations {
+		fmt.Fprintf(&b, "  row %d: %v after %v\n", v.Row, v.Key, v.Prev)
+	}
+	if n := report.NumViolations - len(report.Violations); n > 0 {
+		fmt.Fprintf(&b, "  ... and %d more\n", n)
+	}
+	t.Fatalf("%d of %d rows sort before the row above them, and removing %d rows would restore order:\n%s", report.NumViolations, report.Rows, report.MinRemovals, b.String())
+}
@@ -0,0 +1,180 @@
+package lndstest
+
+import (
+	"cmp"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/danderson/go-lnds/lis"
+	diff "github.com/google/go-cmp/cmp"
+)
+
+// fakeRows is a RowSource over rows of a single int column.
+type fakeRows struct {
+	rows []int
+	cur  int
+	// failAt, if positive, is the 1-based row at which iteration
+	// stops with errFake.
+	failAt int
+}
+
+var errFake = errors.New("connection reset")
+
+func (f *fakeRows) Next() bool {
+	if f.cur == f.failAt && f.failAt > 0 {
+		return false
+	}
+	f.cur++
+	return f.cur <= len(f.rows)
+}
+
+func (f *fakeRows) Scan(dest ...any) error {
+	*dest[0].(*int) = f.rows[f.cur-1]
+	return nil
+}
+
+func (f *fakeRows) Err() error {
+	if f.cur == f.failAt && f.failAt > 0 {
+		return errFake
+	}
+	return nil
+}
+
+func scanInt(r RowSource) (int, error) {
+	var v int
+	err := r.Scan(&v)
+	return v, err
+}
+
+func TestAuditOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rows []int
+		max  int
+		want OrderReport[int]
+	}{
+		{
+			name: "empty",
+			max:  10,
+		},
+		{
+			name: "ordered",
+			rows: []int{1, 2, 2, 3},
+			max:  10,
+			want: OrderReport[int]{Rows: 4},
+		},
+		{
+			name: "one_early",
+			// Only 9 is misplaced, but it makes one adjacent pair
+			// go backwards.
+			rows: []int{1, 9, 2, 3, 4},
+			max:  10,
+			want: OrderReport[int]{
+				Rows:          5,
+				NumViolations: 1,
+				Violations:    []Violation[int]{{Row: 2, Key: 2, Prev: 9}},
+				MinRemovals:   1,
+			},
+		},
+		{
+			name: "capped",
+			rows: []int{5, 4, 3, 2, 1},
+			max:  2,
+			want: OrderReport[int]{
+				Rows:          5,
+				NumViolations: 4,
+				Violations:    []Violation[int]{{Row: 1, Key: 4, Prev: 5}, {Row: 2, Key: 3, Prev: 4}},
+				MinRemovals:   4,
+			},
+		},
+		{
+			name: "zigzag",
+			// Every other row violates, but keeping 1, 2, 3, 4
+			// only removes half of the rest.
+			rows: []int{1, 5, 2, 6, 3, 7, 4},
+			max:  10,
+			want: OrderReport[int]{
+				Rows:          7,
+				NumViolations: 3,
+				Violations:    []Violation[int]{{Row: 2, Key: 2, Prev: 5}, {Row: 4, Key: 3, Prev: 6}, {Row: 6, Key: 4, Prev: 7}},
+				MinRemovals:   3,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := AuditOrder(&fakeRows{rows: tc.rows}, scanInt, cmp.Compare, tc.max)
+			if err != nil {
+				t.Fatalf("AuditOrder failed: %v", err)
+			}
+			if diff := diff.Diff(got, tc.want); diff != "" {
+				t.Errorf("AuditOrder report is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAuditOrderErrors(t *testing.T) {
+	t.Parallel()
+
+	rows := &fakeRows{rows: []int{1, 2, 3}, failAt: 2}
+	if _, err := AuditOrder(rows, scanInt, cmp.Compare, 10); !errors.Is(err, errFake) {
+		t.Errorf("AuditOrder with failing rows returned error %v, want %v", err, errFake)
+	}
+
+	rows = &fakeRows{rows: []int{1, 2, 3}}
+	_, err := AuditOrder(rows, scanInt, cmp.Compare, 10, lis.WithMaxLen(2))
+	var tooLong *lis.TooLongError
+	if !errors.As(err, &tooLong) || tooLong.MaxLen != 2 {
+		t.Errorf("AuditOrder over the length limit returned error %v, want a *lis.TooLongError", err)
+	}
+	if _, err := AuditOrder(&fakeRows{rows: []int{1, 2}}, scanInt, cmp.Compare, 10, lis.WithMaxLen(2)); err != nil {
+		t.Errorf("AuditOrder at the length limit failed: %v", err)
+	}
+
+	errScan := errors.New("bad column")
+	failScan := func(RowSource) (int, error) { return 0, errScan }
+	_, err = AuditOrder(&fakeRows{rows: []int{1}}, failScan, cmp.Compare, 10)
+	if !errors.Is(err, errScan) {
+		t.Errorf("AuditOrder with failing scan returned error %v, want %v", err, errScan)
+	}
+}
+
+func TestRequireRowsOrdered(t *testing.T) {
+	t.Parallel()
+
+	var ok fakeT
+	RequireRowsOrdered(&ok, &fakeRows{rows: []int{1, 2, 3}}, scanInt, cmp.Compare)
+	if len(ok.failures) != 0 {
+		t.Errorf("RequireRowsOrdered failed for ordered rows: %v", ok.failures)
+	}
+
+	var bad fakeT
+	rows := make([]int, 30)
+	for i := range rows {
+		rows[i] = len(rows) - i
+	}
+	RequireRowsOrdered(&bad, &fakeRows{rows: rows}, scanInt, cmp.Compare)
+	if len(bad.failures) != 1 {
+		t.Fatalf("RequireRowsOrdered reported %d failures for reversed rows, want 1", len(bad.failures))
+	}
+	for _, want := range []string{
+		"29 of 30 rows sort before the row above them, and removing 29 rows",
+		"row 1: 29 after 30",
+		"... and 19 more",
+	} {
+		if !strings.Contains(bad.failures[0], want) {
+			t.Errorf("RequireRowsOrdered failure is missing %q:\n%s", want, bad.failures[0])
+		}
+	}
+
+	var broken fakeT
+	RequireRowsOrdered(&broken, &fakeRows{rows: []int{1, 2}, failAt: 1}, scanInt, cmp.Compare)
+	if len(broken.failures) != 1 || !strings.Contains(broken.failures[0], errFake.Error()) {
+		t.Errorf("RequireRowsOrdered failures for broken rows = %v, want one mentioning %q", broken.failures, errFake)
+	}
+}
@@ -0,0 +1,112 @@
+// Package patience implements patience sorting, the card game
+// strategy that underlies the lis package.
+//
+// Patience sorting deals the elements of a list one by one onto a row
+// of piles. Each element goes on the leftmost pile whose top element
+// is greater than it, or on a new pile at the right end of the row if
+// there is none. At the end, the number of piles is the length of the
+// longest non-decreasing subsequence of the list, and the piles can
+// be merged efficiently into the sorted list, since each pile is
+// itself sorted.
+package patience
+
+import (
+	"container/heap"
+
+	"github.com/danderson/go-lnds/search"
+)
+
+// Deal deals lst onto patience piles, ordered by cmp, and returns the
+// resulting structure.
+//
+// piles are the piles from left to right. Each pile lists its
+// elements bottom to top, which is the order in which they were
+// dealt, and is strictly decreasing according to cmp. tops are the
+// top elements of each pile, which are non-decreasing from left to
+// right. sorted is lst sorted by cmp, recovered by merging the piles.
+// The sort is stable.
+//
+// Dealing takes O(n·logn) time, or Θ(n) if lst is already sorted,
+// and merging takes O(n·logp) time, where p is the number of piles.
+func Deal[T any, Slice ~[]T](lst Slice, cmp func(T, T) int) (piles []Slice, tops, sorted Slice) {
+	for _, v := range lst {
+		// Tops are sorted, so the leftmost pile whose top is greater
+		// than v can be found by binary search. The common case of v
+		// going on a new pile is checked first.
+		p := len(tops)
+		if p > 0 && cmp(tops[p-1], v) > 0 {
+			p = search.UpperBound(p-1, func(i int) int { return cmp(tops[i], v) })
+		}
+		if p == len(tops) {
+			piles = append(piles, nil)
+			tops = append(tops, v)
+		}
+		piles[p] = append(piles[p], v)
+		tops[p] = v
+	}
+
+	return piles, tops, merge(piles, cmp, len(lst))
+}
+
+// merge merges the sorted piles into a single sorted list of length
+// n. Each pile is read top to bottom, and ties between piles are
+// broken in favor of the leftmost pile. Equal elements are always
+// dealt onto piles further and further to the right, so this keeps
+// them in their original order.
+func merge[T any, Slice ~[]T](piles []Slice, cmp func(T, T) int, n int) Slice {
+	if n == 0 {
+		return nil
+	}
+
+	h := &pileHeap[T, Slice]{cmp: cmp}
+	for i, p := range piles {
+		h.heads = append(h.heads, pileHead{i, len(p) - 1})
+	}
+	h.piles = piles
+	heap.Init(h)
+
+	ret := make(Slice, 0, n)
+	for h.Len() > 0 {
+		head := &h.heads[0]
+		ret = append(ret, piles[head.pile][head.pos])
+		if head.pos == 0 {
+			heap.Pop(h)
+		} else {
+			head.pos--
+			heap.Fix(h, 0)
+		}
+	}
+	return ret
+}
+
+// pileHead is the position of the next element to merge in a pile.
+type pileHead struct {
+	pile, pos int
+}
+
+// pileHeap is a min-heap of pile heads, for merge.
+type pileHeap[T any, Slice ~[]T] struct {
+	piles []Slice
+	heads []pileHead
+	cmp   func(T, T) int
+}
+
+func (h *pileHeap[T, Slice]) Len() int { return len(h.heads) }
+
+func (h *pileHeap[T, Slice]) Less(i, j int) bool {
+	a, b := h.heads[i], h.heads[j]
+	if c := h.cmp(h.piles[a.pile][a.pos], h.piles[b.pile][b.pos]); c != 0 {
+		return c < 0
+	}
+	return a.pile < b.pile
+}
+
+func (h *pileHeap[T, Slice]) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+
+func (h *pileHeap[T, Slice]) Push(x any) { h.heads = append(h.heads, x.(pileHead)) }
+
+func (h *pileHeap[T, Slice]) Pop() any {
+	ret := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return ret
+}
@@ -0,0 +1,114 @@
+package patience
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/danderson/go-lnds/lis"
+	diff "github.com/google/go-cmp/cmp"
+)
+
+func TestDeal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         []int
+		wantPiles  [][]int
+		wantTops   []int
+		wantSorted []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:       "sorted",
+			in:         []int{1, 2, 3},
+			wantPiles:  [][]int{{1}, {2}, {3}},
+			wantTops:   []int{1, 2, 3},
+			wantSorted: []int{1, 2, 3},
+		},
+		{
+			name:       "reversed",
+			in:         []int{3, 2, 1},
+			wantPiles:  [][]int{{3, 2, 1}},
+			wantTops:   []int{1},
+			wantSorted: []int{1, 2, 3},
+		},
+		{
+			name:       "equal",
+			in:         []int{2, 2, 2},
+			wantPiles:  [][]int{{2}, {2}, {2}},
+			wantTops:   []int{2, 2, 2},
+			wantSorted: []int{2, 2, 2},
+		},
+		{
+			name:       "mixed",
+			in:         []int{7, 2, 8, 1, 3, 4, 10, 6, 9, 5},
+			wantPiles:  [][]int{{7, 2, 1}, {8, 3}, {4}, {10, 6, 5}, {9}},
+			wantTops:   []int{1, 3, 4, 5, 9},
+			wantSorted: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			piles, tops, sorted := Deal(tc.in, cmp.Compare)
+			if diff := diff.Diff(piles, tc.wantPiles); diff != "" {
+				t.Errorf("Deal piles are wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(tops, tc.wantTops); diff != "" {
+				t.Errorf("Deal tops are wrong (-got+want):\n%s", diff)
+			}
+			if diff := diff.Diff(sorted, tc.wantSorted); diff != "" {
+				t.Errorf("Deal sorted output is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDealRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 200
+
+	// Cards have a value to sort by and a serial number, to check
+	// that sorting is stable.
+	type card struct{ val, serial int }
+	byVal := func(a, b card) int { return cmp.Compare(a.val, b.val) }
+
+	for range numIters {
+		input := make([]card, numVals)
+		for i := range input {
+			input[i] = card{rand.Intn(numVals / 3), i}
+		}
+
+		piles, tops, sorted := Deal(input, byVal)
+
+		if got, want := len(piles), lis.Len(input, byVal); got != want {
+			t.Errorf("Deal(%v) made %d piles, want LIS length %d", input, got, want)
+		}
+		for i, p := range piles {
+			for j := 1; j < len(p); j++ {
+				if byVal(p[j], p[j-1]) >= 0 {
+					t.Fatalf("Deal(%v) pile %d is not strictly decreasing: %v", input, i, p)
+				}
+			}
+			if p[len(p)-1] != tops[i] {
+				t.Errorf("Deal(%v) top of pile %d is %v, tops has %v", input, i, p[len(p)-1], tops[i])
+			}
+		}
+		if !slices.IsSortedFunc(tops, byVal) {
+			t.Errorf("Deal(%v) tops are not sorted: %v", input, tops)
+		}
+
+		want := slices.Clone(input)
+		slices.SortStableFunc(want, byVal)
+		if diff := diff.Diff(sorted, want, diff.AllowUnexported(card{})); diff != "" {
+			t.Errorf("Deal(%v) sorted output is wrong (-got+want):\n%s", input, diff)
+		}
+	}
+}
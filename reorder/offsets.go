@@ -0,0 +1,128 @@
+package reorder
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+
+	"github.com/danderson/go-lnds/lis"
+)
+
+// A Record is one message consumed from a partitioned stream, such as
+// a Kafka topic.
+type Record struct {
+	Partition int32
+	Offset    int64
+	Timestamp time.Time
+}
+
+// An Event reports that the records consumed from a partition are
+// further out of order than an OffsetChecker's threshold allows.
+type Event struct {
+	// Record is the record whose arrival raised OutOfOrder.
+	Record Record
+	// Consumed is the number of records consumed from the partition,
+	// including Record.
+	Consumed int
+	// OutOfOrder is the minimum number of the consumed records that
+	// would have to be removed for the rest to have non-decreasing
+	// offsets.
+	OutOfOrder int
+	// Threshold is the checker's threshold, which OutOfOrder
+	// exceeds.
+	Threshold int
+}
+
+// OffsetChecker checks that the records of each partition of a stream
+// are consumed in offset order, and reports partitions whose records
+// are too far out of order.
+//
+// For each partition, the checker tracks the minimum number of
+// records that would have to be removed for the remaining ones to
+// have non-decreasing offsets. Redelivered records, with the same
+// offset as an earlier one, are not out of order. Removal counts are
+// more robust than counting inversions: a single record that arrives
+// far too early only counts once, rather than once for every record
+// that follows it.
+//
+// The checker keeps every record's offset, using O(log n) time per
+// record for a partition of n records so far. Long-running monitors
+// should Reset partitions periodically, such as on every rebalance
+// or checkpoint, and can bound the number of records kept per
+// partition with lis.WithMaxLen.
+//
+// An OffsetChecker is not safe for concurrent use.
+type OffsetChecker struct {
+	threshold int
+	emit      func(Event)
+	opts      []lis.Option
+	parts     map[int32]*partitionState
+}
+
+type partitionState struct {
+	offsets    *lis.Online[int64]
+	consumed   int
+	outOfOrder int
+}
+
+// NewOffsetChecker returns an OffsetChecker that calls emit whenever
+// the out-of-order count of a partition grows beyond threshold. opts
+// apply to the lis.Online that tracks each partition.
+func NewOffsetChecker(threshold int, emit func(Event), opts ...lis.Option) *OffsetChecker {
+	return &OffsetChecker{
+		threshold: threshold,
+		emit:      emit,
+		opts:      opts,
+		parts:     map[int32]*partitionState{},
+	}
+}
+
+// Observe records the consumption of r. If this raises the
+// out-of-order count of r's partition above the checker's threshold,
+// Observe emits an Event before returning. Once a partition is over
+// the threshold, every further increase emits another Event.
+//
+// If r's partition already holds as many records as a lis.WithMaxLen
+// option allows, Observe ignores r and returns an error wrapping a
+// *lis.TooLongError. Reset the partition to keep checking it.
+func (c *OffsetChecker) Observe(r Record) error {
+	p := c.parts[r.Partition]
+	if p == nil {
+		p = &partitionState{offsets: lis.NewOnline(cmp.Compare[int64], c.opts...)}
+		c.parts[r.Partition] = p
+	}
+
+	if err := p.offsets.Push(r.Offset); err != nil {
+		return fmt.Errorf("partition %d: %w", r.Partition, err)
+	}
+	p.consumed++
+	outOfOrder := p.consumed - p.offsets.Len()
+	if outOfOrder == p.outOfOrder {
+		return nil
+	}
+	p.outOfOrder = outOfOrder
+	if outOfOrder > c.threshold {
+		c.emit(Event{
+			Record:     r,
+			Consumed:   p.consumed,
+			OutOfOrder: outOfOrder,
+			Threshold:  c.threshold,
+		})
+	}
+	return nil
+}
+
+// OutOfOrder returns the current out-of-order count of partition,
+// which is 0 if it has no records.
+func (c *OffsetChecker) OutOfOrder(partition int32) int {
+	if p := c.parts[partition]; p != nil {
+		return p.outOfOrder
+	}
+	return 0
+}
+
+// Reset forgets all the records of partition, so that checking starts
+// afresh with the next record.
+func (c *OffsetChecker) Reset(partition int32) {
+	delete(c.parts, partition)
+}
@@ -0,0 +1,185 @@
+package reorder
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/danderson/go-lnds/lis"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOffsetChecker(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// recs returns records for partition, with the given offsets,
+	// consumed one second apart.
+	recs := func(partition int32, offsets ...int64) []Record {
+		var ret []Record
+		for i, o := range offsets {
+			ret = append(ret, Record{partition, o, base.Add(time.Duration(i) * time.Second)})
+		}
+		return ret
+	}
+	interleave := func(a, b []Record) []Record {
+		var ret []Record
+		for len(a) > 0 || len(b) > 0 {
+			if len(a) > 0 {
+				ret, a = append(ret, a[0]), a[1:]
+			}
+			if len(b) > 0 {
+				ret, b = append(ret, b[0]), b[1:]
+			}
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name      string
+		threshold int
+		in        []Record
+		want      []Event
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "in_order",
+			in:   recs(0, 1, 2, 3, 4),
+		},
+		{
+			name: "redelivered",
+			in:   recs(0, 1, 2, 2, 3, 3, 4),
+		},
+		{
+			name:      "under_threshold",
+			threshold: 1,
+			in:        recs(0, 1, 2, 4, 3, 5),
+		},
+		{
+			name: "one_late",
+			in:   recs(0, 1, 2, 4, 3, 5),
+			want: []Event{
+				{Record: recs(0, 1, 2, 4, 3)[3], Consumed: 4, OutOfOrder: 1},
+			},
+		},
+		{
+			name:      "early_counts_once",
+			threshold: 1,
+			// 9 arriving early puts every later record behind it,
+			// but removing 9 alone restores order.
+			in: recs(0, 1, 9, 2, 3, 4, 5),
+		},
+		{
+			name:      "grows_past_threshold",
+			threshold: 1,
+			in:        recs(0, 5, 4, 3, 2, 6),
+			want: []Event{
+				{Record: recs(0, 5, 4, 3)[2], Consumed: 3, OutOfOrder: 2, Threshold: 1},
+				{Record: recs(0, 5, 4, 3, 2)[3], Consumed: 4, OutOfOrder: 3, Threshold: 1},
+			},
+		},
+		{
+			name: "partitions_independent",
+			// Both partitions are in order on their own, although
+			// their offsets interleave out of order.
+			in: interleave(recs(0, 10, 11, 12), recs(1, 1, 2, 3)),
+		},
+		{
+			name: "one_partition_late",
+			in:   interleave(recs(0, 10, 11, 12), recs(1, 2, 1, 3)),
+			want: []Event{
+				{Record: recs(1, 2, 1)[1], Consumed: 2, OutOfOrder: 1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []Event
+			c := NewOffsetChecker(tc.threshold, func(e Event) { got = append(got, e) })
+			for _, r := range tc.in {
+				c.Observe(r)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("OffsetChecker events are wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOffsetCheckerReset(t *testing.T) {
+	t.Parallel()
+
+	var got []Event
+	c := NewOffsetChecker(0, func(e Event) { got = append(got, e) })
+	c.Observe(Record{Partition: 1, Offset: 5})
+	c.Observe(Record{Partition: 1, Offset: 4})
+	if got := c.OutOfOrder(1); got != 1 {
+		t.Errorf("OutOfOrder before Reset = %d, want 1", got)
+	}
+
+	c.Reset(1)
+	if got := c.OutOfOrder(1); got != 0 {
+		t.Errorf("OutOfOrder after Reset = %d, want 0", got)
+	}
+	// After a reset, an offset before those seen so far is not out
+	// of order.
+	c.Observe(Record{Partition: 1, Offset: 3})
+	c.Observe(Record{Partition: 1, Offset: 4})
+	if len(got) != 1 {
+		t.Errorf("got %d events, want 1 from before Reset: %v", len(got), got)
+	}
+}
+
+func TestOffsetCheckerMaxLen(t *testing.T) {
+	t.Parallel()
+
+	c := NewOffsetChecker(0, func(Event) {}, lis.WithMaxLen(2))
+	for _, o := range []int64{1, 2} {
+		if err := c.Observe(Record{Partition: 7, Offset: o}); err != nil {
+			t.Fatalf("Observe under the limit failed: %v", err)
+		}
+	}
+	err := c.Observe(Record{Partition: 7, Offset: 0})
+	var tooLong *lis.TooLongError
+	if !errors.As(err, &tooLong) || tooLong.MaxLen != 2 {
+		t.Fatalf("Observe over the limit returned %v, want a *lis.TooLongError with MaxLen 2", err)
+	}
+	if got := c.OutOfOrder(7); got != 0 {
+		t.Errorf("OutOfOrder after a rejected record = %d, want 0", got)
+	}
+
+	// The limit is per partition.
+	if err := c.Observe(Record{Partition: 8, Offset: 0}); err != nil {
+		t.Errorf("Observe on another partition failed: %v", err)
+	}
+	c.Reset(7)
+	if err := c.Observe(Record{Partition: 7, Offset: 0}); err != nil {
+		t.Errorf("Observe after Reset failed: %v", err)
+	}
+}
+
+func TestOffsetCheckerRandom(t *testing.T) {
+	t.Parallel()
+
+	const numRecords = 200
+	const numIters = 50
+
+	for range numIters {
+		c := NewOffsetChecker(0, func(Event) {})
+		offsets := map[int32][]int64{}
+		for range numRecords {
+			r := Record{Partition: int32(rand.Intn(3)), Offset: int64(rand.Intn(50))}
+			c.Observe(r)
+			offsets[r.Partition] = append(offsets[r.Partition], r.Offset)
+		}
+		for p, o := range offsets {
+			if got, want := c.OutOfOrder(p), Analyze(o).Late; got != want {
+				t.Errorf("OutOfOrder(%d) = %d, want %d for offsets %v", p, got, want, o)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Package reorder computes packet reordering metrics from a sequence
+// of observed packet sequence numbers, in the style of RFC 4737.
+//
+// OffsetChecker applies the same ideas to partitioned message streams,
+// checking online that each partition is consumed in offset order.
+//
+// Sequence numbers are compared as plain ordered values. Callers
+// analyzing protocols whose sequence numbers wrap around, such as
+// TCP, must unwrap them into a monotonic space first.
+package reorder
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/danderson/go-lnds/lis"
+	"github.com/danderson/go-lnds/search"
+)
+
+// Metrics describes the reordering present in a sequence of packets.
+type Metrics struct {
+	// Packets is the number of packets analyzed.
+	Packets int
+	// Late is the minimum number of packets that would have to be
+	// removed for the remaining packets to arrive in order. It is
+	// Packets minus the length of the longest non-decreasing
+	// subsequence of sequence numbers.
+	Late int
+	// Reordered is the number of reordered packets as defined by RFC
+	// 4737 section 3.3: packets whose sequence number is lower than
+	// that of some packet that arrived before them.
+	Reordered int
+	// MaxExtent is the largest reordering extent (RFC 4737 section
+	// 4.2) of any reordered packet: the number of packets between a
+	// reordered packet's arrival and the earliest earlier packet with
+	// a higher sequence number, counting that packet.
+	MaxExtent int
+	// MaxDisplacement is the largest distance between a packet's
+	// arrival position and its position in sequence number order.
+	// Packets with equal sequence numbers keep their arrival order.
+	MaxDisplacement int
+}
+
+// Analyze returns reordering metrics for packets that arrived with
+// the given sequence numbers, in arrival order.
+func Analyze[T cmp.Ordered](seqs []T) Metrics {
+	ret := Metrics{
+		Packets: len(seqs),
+		Late:    len(seqs) - lis.Len(seqs, cmp.Compare[T]),
+	}
+	if len(seqs) == 0 {
+		return ret
+	}
+
+	// highest[i] is the index of the first packet with the highest
+	// sequence number among seqs[:i+1]. Its sequence numbers are
+	// non-decreasing, so for a reordered packet the earliest packet
+	// with a higher sequence number can be found by bisection.
+	highest := make([]int, len(seqs))
+	for i := range seqs {
+		highest[i] = i
+		if i > 0 && seqs[highest[i-1]] >= seqs[i] {
+			highest[i] = highest[i-1]
+		}
+	}
+	for i, s := range seqs {
+		if i == 0 || seqs[highest[i-1]] <= s {
+			continue
+		}
+		ret.Reordered++
+		first := search.UpperBound(i, func(j int) int {
+			return cmp.Compare(seqs[highest[j]], s)
+		})
+		ret.MaxExtent = max(ret.MaxExtent, i-first)
+	}
+
+	order := make([]int, len(seqs))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(seqs[a], seqs[b])
+	})
+	for sortedPos, i := range order {
+		ret.MaxDisplacement = max(ret.MaxDisplacement, sortedPos-i, i-sortedPos)
+	}
+
+	return ret
+}
@@ -0,0 +1,59 @@
+package reorder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   []uint32
+		want Metrics
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "in_order",
+			in:   []uint32{1, 2, 3, 4},
+			want: Metrics{Packets: 4},
+		},
+		{
+			name: "one_late",
+			in:   []uint32{1, 2, 4, 5, 3, 6},
+			want: Metrics{Packets: 6, Late: 1, Reordered: 1, MaxExtent: 2, MaxDisplacement: 2},
+		},
+		{
+			name: "one_early",
+			// 5 arriving early makes 2, 3 and 4 reordered, but
+			// removing 5 alone restores order.
+			in:   []uint32{1, 5, 2, 3, 4, 6},
+			want: Metrics{Packets: 6, Late: 1, Reordered: 3, MaxExtent: 3, MaxDisplacement: 3},
+		},
+		{
+			name: "rfc4737_example",
+			// RFC 4737 section 4.2.3, example with extent 3 for
+			// packet 4.
+			in:   []uint32{1, 2, 3, 5, 6, 7, 4, 8},
+			want: Metrics{Packets: 8, Late: 1, Reordered: 1, MaxExtent: 3, MaxDisplacement: 3},
+		},
+		{
+			name: "duplicates",
+			in:   []uint32{1, 2, 2, 3},
+			want: Metrics{Packets: 4},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Analyze(tc.in)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("Analyze(%v) is wrong (-got+want):\n%s", tc.in, diff)
+			}
+		})
+	}
+}
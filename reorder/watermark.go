@@ -0,0 +1,42 @@
+package reorder
+
+import (
+	"slices"
+	"time"
+)
+
+// WatermarkDelay returns the smallest watermark delay that, applied to
+// events with the given event times in arrival order, would have let
+// all but at most k of them be processed in order.
+//
+// A watermark delay of d means that when an event with time t
+// arrives, all events with times before t-d are assumed to have
+// already arrived. An event is late if it arrives after the watermark
+// has passed its time, i.e. if some earlier event's time is more than
+// d after its own.
+//
+// A negative k is treated as 0.
+func WatermarkDelay(times []time.Time, k int) time.Duration {
+	k = max(k, 0)
+
+	// lateness[i] is the smallest delay for which event i is not
+	// late.
+	var lateness []time.Duration
+	var latest time.Time
+	for i, t := range times {
+		if i > 0 && t.Before(latest) {
+			lateness = append(lateness, latest.Sub(t))
+		}
+		if i == 0 || t.After(latest) {
+			latest = t
+		}
+	}
+	if len(lateness) <= k {
+		return 0
+	}
+
+	// Allowing k late events means the delay must cover all but the
+	// k most delayed events.
+	slices.Sort(lateness)
+	return lateness[len(lateness)-1-k]
+}
@@ -0,0 +1,47 @@
+package reorder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatermarkDelay(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(secs ...int) []time.Time {
+		var ret []time.Time
+		for _, s := range secs {
+			ret = append(ret, base.Add(time.Duration(s)*time.Second))
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name  string
+		times []time.Time
+		k     int
+		want  time.Duration
+	}{
+		{"nil", nil, 0, 0},
+		{"in_order", at(1, 2, 3), 0, 0},
+		{"equal_times", at(1, 1, 1), 0, 0},
+		{"one_late", at(1, 5, 3, 6), 0, 2 * time.Second},
+		{"one_late_tolerated", at(1, 5, 3, 6), 1, 0},
+		{"several_late", at(10, 1, 9, 5, 11, 8), 0, 9 * time.Second},
+		{"several_late_k1", at(10, 1, 9, 5, 11, 8), 1, 5 * time.Second},
+		{"several_late_k2", at(10, 1, 9, 5, 11, 8), 2, 3 * time.Second},
+		{"several_late_k3", at(10, 1, 9, 5, 11, 8), 3, time.Second},
+		{"several_late_k4", at(10, 1, 9, 5, 11, 8), 4, 0},
+		{"negative_k_in_order", at(1, 2), -1, 0},
+		{"negative_k", at(1, 5, 3, 6), -1, 2 * time.Second},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := WatermarkDelay(tc.times, tc.k); got != tc.want {
+				t.Errorf("WatermarkDelay(k=%d) = %v, want %v", tc.k, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Package search provides binary searches over sorted sequences that
+// are only accessible by index.
+//
+// Unlike the slices and sort packages, the searches in this package
+// take a single closure that compares the element at a given index
+// against the search target. This lets callers search sequences that
+// are not materialized as a slice of the compared values, for example
+// a slice of indices into some other slice.
+package search
+
+// LowerBound returns the smallest index i in [0, n) for which
+// cmp(i) >= 0, or n if there is no such index. cmp(i) must compare
+// the i-th element of the sequence to the search target, and the
+// sequence must be sorted such that cmp is non-decreasing over
+// [0, n).
+//
+// If the target is present in the sequence, LowerBound returns the
+// index of its first occurrence. Otherwise, it returns the position
+// where the target would need to be inserted to keep the sequence
+// sorted.
+func LowerBound(n int, cmp func(i int) int) int {
+	low, high := uint(0), uint(n)
+	for low < high {
+		mid := (low + high) / 2
+		if cmp(int(mid)) < 0 {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return int(low)
+}
+
+// UpperBound returns the smallest index i in [0, n) for which
+// cmp(i) > 0, or n if there is no such index. cmp(i) must compare the
+// i-th element of the sequence to the search target, and the sequence
+// must be sorted such that cmp is non-decreasing over [0, n).
+//
+// If the target is present in the sequence, UpperBound returns the
+// index one past its final occurrence. Otherwise, it returns the same
+// value as LowerBound.
+func UpperBound(n int, cmp func(i int) int) int {
+	low, high := uint(0), uint(n)
+	for low < high {
+		mid := (low + high) / 2
+		if cmp(int(mid)) > 0 {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return int(low)
+}
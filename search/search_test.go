@@ -0,0 +1,69 @@
+package search
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestBounds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		in        []int
+		target    int
+		wantLower int
+		wantUpper int
+	}{
+		{"nil", nil, 1, 0, 0},
+		{"singleton_before", []int{2}, 1, 0, 0},
+		{"singleton_equal", []int{2}, 2, 0, 1},
+		{"singleton_after", []int{2}, 3, 1, 1},
+		{"distinct_present", []int{1, 2, 3, 4}, 3, 2, 3},
+		{"distinct_absent", []int{1, 3, 5, 7}, 4, 2, 2},
+		{"run_of_equals", []int{1, 2, 2, 2, 3}, 2, 1, 4},
+		{"all_equal", []int{2, 2, 2}, 2, 0, 3},
+		{"before_all", []int{2, 2, 2}, 1, 0, 0},
+		{"after_all", []int{2, 2, 2}, 3, 3, 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmp := func(i int) int { return cmp.Compare(tc.in[i], tc.target) }
+			if got := LowerBound(len(tc.in), cmp); got != tc.wantLower {
+				t.Errorf("LowerBound(%v, %d) = %d, want %d", tc.in, tc.target, got, tc.wantLower)
+			}
+			if got := UpperBound(len(tc.in), cmp); got != tc.wantUpper {
+				t.Errorf("UpperBound(%v, %d) = %d, want %d", tc.in, tc.target, got, tc.wantUpper)
+			}
+		})
+	}
+}
+
+func TestBoundsRandom(t *testing.T) {
+	t.Parallel()
+
+	const numVals = 50
+	const numIters = 100
+
+	for range numIters {
+		in := make([]int, rand.Intn(numVals))
+		for i := range in {
+			in[i] = rand.Intn(numVals / 2)
+		}
+		slices.Sort(in)
+		target := rand.Intn(numVals/2 + 2)
+		cmp := func(i int) int { return cmp.Compare(in[i], target) }
+
+		wantLower, _ := slices.BinarySearch(in, target)
+		wantUpper, _ := slices.BinarySearch(in, target+1)
+		if got := LowerBound(len(in), cmp); got != wantLower {
+			t.Errorf("LowerBound(%v, %d) = %d, want %d", in, target, got, wantLower)
+		}
+		if got := UpperBound(len(in), cmp); got != wantUpper {
+			t.Errorf("UpperBound(%v, %d) = %d, want %d", in, target, got, wantUpper)
+		}
+	}
+}
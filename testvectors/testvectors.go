@@ -0,0 +1,352 @@
+// Package testvectors generates a corpus of inputs and the outputs
+// that lis computes for them, for checking that reimplementations in
+// other languages agree with this one.
+//
+// The corpus is versioned. Version changes whenever the set of
+// vectors or the meaning of any field changes, so that other
+// implementations can tell when they need to pick up a new corpus.
+// The current corpus is checked in as testdata/v<Version>.json, and
+// regenerated with:
+//
+//	go test ./testvectors -update
+//
+// All inputs are integers compared in their natural order.
+//
+// Version 2 added the priority, protected and msis modes, and the
+// weights, protected, score and infeasible fields they use, and
+// removed duplicate inputs.
+package testvectors
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/danderson/go-lnds/lis"
+)
+
+// Version is the version of the corpus that Generate produces.
+const Version = 2
+
+// A Corpus is a versioned set of test vectors.
+type Corpus struct {
+	Version int      `json:"version"`
+	Modes   []Mode   `json:"modes"`
+	Vectors []Vector `json:"vectors"`
+}
+
+// A Vector is one input to one mode, and the expected output.
+type Vector struct {
+	// Mode is the function that computes the output, one of the
+	// names in Modes.
+	Mode string `json:"mode"`
+	// Param is the mode's numeric parameter, for modes that take
+	// one, and zero otherwise.
+	Param int   `json:"param,omitempty"`
+	Input []int `json:"input"`
+	// Weights are the weights of the elements of Input, for modes
+	// that weigh elements, and nil otherwise.
+	Weights []int `json:"weights,omitempty"`
+	// Protected are the increasing indices in Input of the elements
+	// that the subsequence must contain, for modes that protect
+	// elements, and nil otherwise.
+	Protected []int `json:"protected,omitempty"`
+
+	// Length is the length of the longest subsequence. For the msis
+	// mode, which doesn't maximize length, it is the length of the
+	// subsequence lis.MSIS returns, and only informative.
+	Length int `json:"length"`
+	// Indices are the increasing indices in Input of the
+	// subsequence, for modes that specify exactly which of several
+	// longest subsequences they return, and if Length is not 0. It is
+	// omitted for other modes, whose implementations only need to
+	// agree on Length.
+	Indices []int `json:"indices,omitempty"`
+	// Score is the total weight or sum of the subsequence, for
+	// scored modes, and zero otherwise.
+	Score int `json:"score,omitempty"`
+	// Infeasible is whether the mode's constraints cannot be
+	// satisfied, in which case Length is 0.
+	Infeasible bool `json:"infeasible,omitempty"`
+}
+
+// A Mode is a variant of the longest subsequence problem.
+type Mode struct {
+	// Name identifies the mode in Vector.Mode.
+	Name string `json:"name"`
+	// Doc describes the mode for implementers in other languages.
+	Doc string `json:"doc"`
+	// HasParam is whether the mode takes a parameter.
+	HasParam bool `json:"has_param"`
+	// HasWeights is whether vectors include Weights.
+	HasWeights bool `json:"has_weights"`
+	// HasProtected is whether vectors include Protected.
+	HasProtected bool `json:"has_protected"`
+	// Canonical is whether the mode specifies exactly which
+	// subsequence it returns, in which case vectors include
+	// Indices.
+	Canonical bool `json:"canonical"`
+	// Scored is whether vectors include Score, which
+	// implementations must agree on.
+	Scored bool `json:"scored"`
+
+	// run returns the subsequence that the mode computes for v, as
+	// indices into v.Input for canonical modes, and as values
+	// otherwise. For scored modes, it also returns the score.
+	run func(v Vector) (sorted []int, score int, err error)
+}
+
+// Modes are the modes that the corpus covers.
+var Modes = []Mode{
+	{
+		Name:      "lis",
+		Doc:       "Longest non-decreasing subsequence. Among several, the one with lexicographically greatest indices, which also has the lexicographically smallest values (lis.LIS).",
+		Canonical: true,
+		run: func(v Vector) ([]int, int, error) {
+			sorted, _ := lis.LISIndices(v.Input, cmp.Compare)
+			return sorted, 0, nil
+		},
+	},
+	{
+		Name:      "lis_largest",
+		Doc:       "Longest non-decreasing subsequence. Among several, the one with lexicographically smallest indices, which also has the lexicographically greatest values (lis.LISLargest).",
+		Canonical: true,
+		run: func(v Vector) ([]int, int, error) {
+			return indicesOf(v.Input, lis.LISLargest[int, []int]), 0, nil
+		},
+	},
+	{
+		Name: "lds",
+		Doc:  "Longest strictly decreasing subsequence (lis.LDS).",
+		run: func(v Vector) ([]int, int, error) {
+			return indicesOf(v.Input, lis.LDS[int, []int]), 0, nil
+		},
+	},
+	{
+		Name: "lnis",
+		Doc:  "Longest non-increasing subsequence (lis.LNIS).",
+		run: func(v Vector) ([]int, int, error) {
+			return indicesOf(v.Input, lis.LNIS[int, []int]), 0, nil
+		},
+	},
+	{
+		Name:     "min_diff",
+		Doc:      "Longest subsequence where each element is at least param greater than the previous (lis.LISMinDiff).",
+		HasParam: true,
+		run: func(v Vector) ([]int, int, error) {
+			sorted, _ := lis.LISMinDiff(v.Input, identity, v.Param)
+			return sorted, 0, nil
+		},
+	},
+	{
+		Name:     "max_diff",
+		Doc:      "Longest subsequence where each element is greater than or equal to the previous, by at most param (lis.LISMaxDiff).",
+		HasParam: true,
+		run: func(v Vector) ([]int, int, error) {
+			sorted, _ := lis.LISMaxDiff(v.Input, identity, v.Param)
+			return sorted, 0, nil
+		},
+	},
+	{
+		Name:     "window",
+		Doc:      "Longest non-decreasing subsequence where consecutive elements are at most param positions apart in the input (lis.LISWindow).",
+		HasParam: true,
+		run: func(v Vector) ([]int, int, error) {
+			sorted, _ := lis.LISWindow(v.Input, cmp.Compare, v.Param)
+			return sorted, 0, nil
+		},
+	},
+	{
+		Name:       "priority",
+		Doc:        "Longest non-decreasing subsequence. Among several, one with the greatest total weight, which is the score (lis.LISPriority).",
+		HasWeights: true,
+		Scored:     true,
+		run: func(v Vector) ([]int, int, error) {
+			sorted := indicesOf(v.Input, func(tagged []int, cmp func(int, int) int) ([]int, []int) {
+				return lis.LISPriority(tagged, cmp, func(i int) int { return v.Weights[i] })
+			})
+			score := 0
+			for _, i := range sorted {
+				score += v.Weights[i]
+			}
+			return sorted, score, nil
+		},
+	},
+	{
+		Name:         "protected",
+		Doc:          "Longest non-decreasing subsequence that contains every protected element. Infeasible if the protected elements are not themselves non-decreasing (lis.LISProtected).",
+		HasProtected: true,
+		run: func(v Vector) ([]int, int, error) {
+			sorted, _, err := lis.LISProtected(v.Input, cmp.Compare, func(i int) bool {
+				_, found := slices.BinarySearch(v.Protected, i)
+				return found
+			})
+			return sorted, 0, err
+		},
+	},
+	{
+		Name:   "msis",
+		Doc:    "Non-decreasing subsequence with the greatest sum, which is the score. It need not be a longest one, so implementations only need to agree on the score. For a non-empty input, the subsequence is not empty, even if every element is negative (lis.MSIS).",
+		Scored: true,
+		run: func(v Vector) ([]int, int, error) {
+			sorted, _ := lis.MSIS(v.Input)
+			score := 0
+			for _, x := range sorted {
+				score += x
+			}
+			return sorted, score, nil
+		},
+	},
+}
+
+func identity(v int) int { return v }
+
+// indicesOf returns the indices of the subsequence that fn picks.
+// Elements are tagged with their index so that the subsequence's
+// values identify them uniquely.
+func indicesOf(input []int, fn func([]int, func(int, int) int) ([]int, []int)) []int {
+	n := len(input)
+	tagged := make([]int, n)
+	for i, v := range input {
+		tagged[i] = v*n + i
+	}
+	// Tagged values are compared by the value they tag. Floor
+	// division keeps negative values in order.
+	untag := func(t int) int {
+		if t < 0 {
+			return (t - n + 1) / n
+		}
+		return t / n
+	}
+	sorted, _ := fn(tagged, func(a, b int) int {
+		return cmp.Compare(untag(a), untag(b))
+	})
+	ret := make([]int, len(sorted))
+	for i, t := range sorted {
+		ret[i] = t - untag(t)*n
+	}
+	return ret
+}
+
+// fixed are hand-picked inputs, covering edge cases.
+var fixed = [][]int{
+	{},
+	{7},
+	{1, 2, 3, 4, 5},
+	{5, 4, 3, 2, 1},
+	{3, 3, 3, 3},
+	{1, 3, 2, 4, 3},
+	{2, 1, 3, 4, 3, 6, 3, 5, 8, 3, 7},
+	{0, 8, 4, 12, 2, 10, 6, 14, 1, 9, 5, 13, 3, 11, 7, 15},
+	{-3, -1, -2},
+	{2, -5, 3, -1, 4, 0},
+}
+
+// Generate returns the corpus. It is deterministic: the same seed
+// always produces the same corpus. The checked in corpus uses seed 1.
+func Generate(seed uint64) Corpus {
+	r := rand.New(rand.NewPCG(seed, Version))
+
+	// Every input is distinct, so that no two vectors of a mode
+	// without a parameter are the same.
+	inputs := slices.Clone(fixed)
+	seen := map[string]bool{}
+	for _, input := range inputs {
+		seen[fmt.Sprint(input)] = true
+	}
+	const numRandom = 24
+	for len(inputs) < len(fixed)+numRandom {
+		// Small inputs from a small alphabet, so that ties between
+		// several longest subsequences are common.
+		input := make([]int, r.IntN(17))
+		for i := range input {
+			input[i] = r.IntN(8)
+		}
+		if key := fmt.Sprint(input); !seen[key] {
+			seen[key] = true
+			inputs = append(inputs, input)
+		}
+	}
+
+	ret := Corpus{Version: Version, Modes: Modes}
+	for _, m := range Modes {
+		for _, input := range inputs {
+			v := Vector{Mode: m.Name, Input: input}
+			if m.HasParam {
+				v.Param = r.IntN(4)
+			}
+			if m.HasWeights {
+				for range input {
+					v.Weights = append(v.Weights, r.IntN(7)-3)
+				}
+			}
+			if m.HasProtected {
+				// Protect about one element in four, which makes
+				// both feasible and infeasible vectors common.
+				for i := range input {
+					if r.IntN(4) == 0 {
+						v.Protected = append(v.Protected, i)
+					}
+				}
+			}
+
+			sorted, score, err := m.run(v)
+			switch {
+			case errors.Is(err, lis.ErrInfeasibleConstraint):
+				v.Infeasible = true
+			case err != nil:
+				panic(fmt.Sprintf("mode %s failed on %v: %v", m.Name, input, err))
+			}
+			v.Length = len(sorted)
+			if m.Canonical && len(sorted) > 0 {
+				v.Indices = sorted
+			}
+			if m.Scored {
+				v.Score = score
+			}
+			ret.Vectors = append(ret.Vectors, v)
+		}
+	}
+	return ret
+}
+
+// WriteJSON writes c to w as JSON, with one mode or vector per line
+// so that changes to the corpus produce readable diffs.
+func WriteJSON(w io.Writer, c Corpus) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "{\"version\": %d,\n\"modes\": [\n", c.Version)
+	for i, m := range c.Modes {
+		if err := writeLine(&buf, m, i == len(c.Modes)-1); err != nil {
+			return err
+		}
+	}
+	buf.WriteString("],\n\"vectors\": [\n")
+	for i, v := range c.Vectors {
+		if err := writeLine(&buf, v, i == len(c.Vectors)-1); err != nil {
+			return err
+		}
+	}
+	buf.WriteString("]}\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// writeLine writes v to buf as a line of JSON, followed by a comma
+// unless last is true.
+func writeLine(buf *bytes.Buffer, v any, last bool) error {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(bs)
+	if !last {
+		buf.WriteByte(',')
+	}
+	buf.WriteByte('\n')
+	return nil
+}
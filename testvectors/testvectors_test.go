@@ -0,0 +1,134 @@
+package testvectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	diff "github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+var update = flag.Bool("update", false, "rewrite the checked in corpus")
+
+func corpusPath() string {
+	return filepath.Join("testdata", fmt.Sprintf("v%d.json", Version))
+}
+
+func TestCorpusUpToDate(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, Generate(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if *update {
+		if err := os.WriteFile(corpusPath(), buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(corpusPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := diff.Diff(buf.String(), string(want)); diff != "" {
+		t.Errorf("checked in corpus is stale, bump Version if the change is intended and run go test -update (-got+want):\n%s", diff)
+	}
+}
+
+func TestCorpusRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := Generate(1)
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	var got Corpus
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := diff.Diff(got, want, cmpopts.IgnoreUnexported(Mode{})); diff != "" {
+		t.Errorf("corpus JSON round trip is wrong (-got+want):\n%s", diff)
+	}
+}
+
+func TestVectorsConsistent(t *testing.T) {
+	t.Parallel()
+
+	modes := map[string]Mode{}
+	for _, m := range Modes {
+		modes[m.Name] = m
+	}
+
+	for _, v := range Generate(1).Vectors {
+		m, ok := modes[v.Mode]
+		if !ok {
+			t.Fatalf("vector has unknown mode %q", v.Mode)
+		}
+		if !m.HasParam && v.Param != 0 {
+			t.Errorf("%s vector has param %d, but mode takes none", v.Mode, v.Param)
+		}
+		if (m.HasWeights && len(v.Weights) != len(v.Input)) || (!m.HasWeights && v.Weights != nil) {
+			t.Errorf("%s(%v) has weights %v, want one per element if and only if the mode has weights", v.Mode, v.Input, v.Weights)
+		}
+		if !m.HasProtected && v.Protected != nil {
+			t.Errorf("%s vector has protected elements, but mode has none", v.Mode)
+		}
+		if !slices.IsSorted(v.Protected) || (len(v.Protected) > 0 && v.Protected[len(v.Protected)-1] >= len(v.Input)) {
+			t.Errorf("%s(%v) protected indices %v are not increasing indices into the input", v.Mode, v.Input, v.Protected)
+		}
+		if !m.Scored && v.Score != 0 {
+			t.Errorf("%s vector has score %d, but mode is not scored", v.Mode, v.Score)
+		}
+		if v.Infeasible && v.Length != 0 {
+			t.Errorf("%s(%v) is infeasible, but has length %d", v.Mode, v.Input, v.Length)
+		}
+		if !m.Canonical {
+			if v.Indices != nil {
+				t.Errorf("%s vector has indices, but mode is not canonical", v.Mode)
+			}
+			continue
+		}
+		if len(v.Indices) != v.Length {
+			t.Errorf("%s(%v) has %d indices, want length %d", v.Mode, v.Input, len(v.Indices), v.Length)
+		}
+		if !slices.IsSorted(v.Indices) {
+			t.Errorf("%s(%v) indices %v are not increasing", v.Mode, v.Input, v.Indices)
+		}
+		for k := 1; k < len(v.Indices); k++ {
+			if v.Input[v.Indices[k]] < v.Input[v.Indices[k-1]] {
+				t.Errorf("%s(%v) indices %v do not select a non-decreasing subsequence", v.Mode, v.Input, v.Indices)
+				break
+			}
+		}
+	}
+}
+
+func TestVectorsDistinct(t *testing.T) {
+	t.Parallel()
+
+	seen := map[string]bool{}
+	for _, v := range Generate(1).Vectors {
+		v.Length, v.Indices, v.Score, v.Infeasible = 0, nil, 0, false
+		key := fmt.Sprintf("%+v", v)
+		if seen[key] {
+			t.Errorf("duplicate vector for %s(%v)", v.Mode, v.Input)
+		}
+		seen[key] = true
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	t.Parallel()
+
+	if diff := diff.Diff(Generate(1), Generate(1), cmpopts.IgnoreUnexported(Mode{})); diff != "" {
+		t.Errorf("Generate is not deterministic (-first+second):\n%s", diff)
+	}
+}
@@ -0,0 +1,497 @@
+// Package fuzzshims exposes the lis package's fuzz targets as plain
+// functions, so that they can be driven by go test -fuzz from any
+// module, or by other fuzzing engines.
+//
+// Each Check function decodes an arbitrary byte slice into an input
+// for one mode of the lis package, runs that mode, and checks every
+// invariant of the result: that it partitions the input in order,
+// that the subsequence obeys the mode's ordering and constraints, and
+// that it is optimal, according to a slow but obviously correct
+// reference implementation. It returns an error describing the first
+// violation, or nil. A harness in another module looks like:
+//
+//	func FuzzPriority(f *testing.F) {
+//		for _, seed := range fuzzshims.Seeds {
+//			f.Add(seed)
+//		}
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			if err := fuzzshims.CheckPriority(data); err != nil {
+//				t.Fatal(err)
+//			}
+//		})
+//	}
+//
+// Decoding is deterministic and total: every byte slice decodes to
+// some valid input, so that a crashing input found by one engine can
+// be replayed anywhere. Inputs are truncated to MaxLen elements, to
+// keep the reference implementations fast.
+//
+// This package is experimental, and its decoders may change between
+// versions of the module, which invalidates existing corpora.
+package fuzzshims
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+
+	"github.com/danderson/go-lnds/lis"
+)
+
+// MaxLen is the maximum number of elements that the decoders produce.
+// Longer inputs are truncated.
+const MaxLen = 512
+
+// Seeds are inputs that exercise every target's interesting cases,
+// for seeding fuzz corpora.
+var Seeds = [][]byte{
+	{},
+	{1},
+	{1, 2, 3, 4},
+	{4, 3, 2, 1},
+	{2, 2, 2, 2},
+	{2, 1, 3, 4, 3, 6, 3, 5, 8, 3, 7},
+	{3, 0xc1, 2, 0xc5, 4, 0xc2, 6},
+	{0x80, 1, 0xff, 200, 0, 7, 0x7f, 9},
+}
+
+// Targets lists every check in this package by name, for harnesses
+// that drive all of them.
+var Targets = map[string]func(data []byte) error{
+	"LIS":       CheckLIS,
+	"Strict":    CheckStrict,
+	"LDS":       CheckLDS,
+	"LNIS":      CheckLNIS,
+	"Priority":  CheckPriority,
+	"Protected": CheckProtected,
+	"MinDiff":   CheckMinDiff,
+	"MaxDiff":   CheckMaxDiff,
+	"Window":    CheckWindow,
+	"MSIS":      CheckMSIS,
+}
+
+// DecodeInts decodes data into a list of integers in [0, 256), one
+// per byte.
+func DecodeInts(data []byte) []int {
+	data = data[:min(len(data), MaxLen)]
+	ret := make([]int, len(data))
+	for i, b := range data {
+		ret[i] = int(b)
+	}
+	return ret
+}
+
+// DecodeWeighted decodes data into a list of integers in [0, 256) and
+// a weight in [-128, 128) for each, from consecutive pairs of bytes.
+// A trailing odd byte is ignored.
+func DecodeWeighted(data []byte) (lst, weights []int) {
+	n := min(len(data)/2, MaxLen)
+	lst = make([]int, n)
+	weights = make([]int, n)
+	for i := range n {
+		lst[i] = int(data[2*i])
+		weights[i] = int(int8(data[2*i+1]))
+	}
+	return lst, weights
+}
+
+// DecodeProtected decodes data into a list of integers in [0, 64),
+// one per byte, and whether each is protected. The low 6 bits of a
+// byte are its value, and it is protected if both of its top 2 bits
+// are set, so that about a quarter of elements are protected.
+func DecodeProtected(data []byte) (lst []int, protected []bool) {
+	data = data[:min(len(data), MaxLen)]
+	lst = make([]int, len(data))
+	protected = make([]bool, len(data))
+	for i, b := range data {
+		lst[i] = int(b & 0x3f)
+		protected[i] = b&0xc0 == 0xc0
+	}
+	return lst, protected
+}
+
+// DecodeParam decodes data into a parameter in [0, 256), from its
+// first byte, and a list of integers decoded from the rest as by
+// DecodeInts. The parameter is 0 if data is empty.
+func DecodeParam(data []byte) (param int, lst []int) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+	return int(data[0]), DecodeInts(data[1:])
+}
+
+// CheckLIS checks lis.LIS, which must return a longest non-decreasing
+// subsequence.
+func CheckLIS(data []byte) error {
+	items := newItems(DecodeInts(data))
+	sorted, rest := lis.LIS(items, compareItems)
+	return check("LIS", items, sorted, rest, chain{
+		ok: func(a, b item) bool { return a.val <= b.val },
+	})
+}
+
+// CheckStrict checks lis.Longest with lis.Strict, which must return a
+// longest strictly increasing subsequence, and lis.LongestLen and
+// lis.LongestIndices, which must agree with it.
+func CheckStrict(data []byte) error {
+	items := newItems(DecodeInts(data))
+	sorted, rest := lis.Longest(items, compareItems, lis.Strict)
+	if err := check("Longest(Strict)", items, sorted, rest, chain{
+		ok: func(a, b item) bool { return a.val < b.val },
+	}); err != nil {
+		return err
+	}
+
+	if got, want := lis.LongestLen(items, compareItems, lis.Strict), len(sorted); got != want {
+		return fmt.Errorf("LongestLen(Strict) = %d, but Longest(Strict) has length %d", got, want)
+	}
+	idxs := lis.LongestIndices(items, compareItems, lis.Strict)
+	if len(idxs) != len(sorted) {
+		return fmt.Errorf("LongestIndices(Strict) has length %d, but Longest(Strict) has length %d", len(idxs), len(sorted))
+	}
+	for k, i := range idxs {
+		if i != sorted[k].idx {
+			return fmt.Errorf("LongestIndices(Strict)[%d] = %d, but Longest(Strict) kept index %d", k, i, sorted[k].idx)
+		}
+	}
+	return nil
+}
+
+// CheckLDS checks lis.LDS, which must return a longest strictly
+// decreasing subsequence.
+func CheckLDS(data []byte) error {
+	items := newItems(DecodeInts(data))
+	sorted, rest := lis.LDS(items, compareItems)
+	return check("LDS", items, sorted, rest, chain{
+		ok: func(a, b item) bool { return a.val > b.val },
+	})
+}
+
+// CheckLNIS checks lis.LNIS, which must return a longest
+// non-increasing subsequence.
+func CheckLNIS(data []byte) error {
+	items := newItems(DecodeInts(data))
+	sorted, rest := lis.LNIS(items, compareItems)
+	return check("LNIS", items, sorted, rest, chain{
+		ok: func(a, b item) bool { return a.val >= b.val },
+	})
+}
+
+// CheckPriority checks lis.LISPriority, which must return a longest
+// non-decreasing subsequence, with the greatest total weight among
+// all of them.
+func CheckPriority(data []byte) error {
+	lst, weights := DecodeWeighted(data)
+	items := newItems(lst)
+	sorted, rest := lis.LISPriority(items, compareItems, func(i int) int { return weights[i] })
+	if err := check("LISPriority", items, sorted, rest, chain{
+		ok: func(a, b item) bool { return a.val <= b.val },
+	}); err != nil {
+		return err
+	}
+
+	got := 0
+	for _, it := range sorted {
+		got += weights[it.idx]
+	}
+	if want := bestWeight(lst, weights); got != want {
+		return fmt.Errorf("LISPriority subsequence %v has total weight %d, want %d", vals(sorted), got, want)
+	}
+	return nil
+}
+
+// CheckProtected checks lis.LISProtected, which must return a longest
+// non-decreasing subsequence that contains every protected element,
+// or a *lis.ConflictError naming two adjacent protected elements that
+// are out of order, if there is no such subsequence.
+func CheckProtected(data []byte) error {
+	lst, protected := DecodeProtected(data)
+	items := newItems(lst)
+	sorted, rest, err := lis.LISProtected(items, compareItems, func(i int) bool { return protected[i] })
+
+	var conflict *lis.ConflictError
+	if wantConflict := protectedConflict(lst, protected); wantConflict {
+		if !errors.As(err, &conflict) || !errors.Is(err, lis.ErrInfeasibleConstraint) {
+			return fmt.Errorf("LISProtected returned error %v for out of order protected elements, want a *ConflictError", err)
+		}
+		i, j := conflict.I, conflict.J
+		if i < 0 || j >= len(lst) || i >= j || !protected[i] || !protected[j] || lst[i] <= lst[j] {
+			return fmt.Errorf("LISProtected reported conflict %d, %d, which is not a pair of out of order protected elements", i, j)
+		}
+		for k := i + 1; k < j; k++ {
+			if protected[k] {
+				return fmt.Errorf("LISProtected reported conflict %d, %d, but %d between them is also protected", i, j, k)
+			}
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("LISProtected returned error %v, but the protected elements are in order", err)
+	}
+
+	// A chain can't skip over a protected element, nor start after
+	// or end before one.
+	firstProtected, lastProtected := len(lst), -1
+	for i, p := range protected {
+		if p {
+			firstProtected = min(firstProtected, i)
+			lastProtected = i
+		}
+	}
+	skipsProtected := func(i, j int) bool {
+		for k := i + 1; k < j; k++ {
+			if protected[k] {
+				return true
+			}
+		}
+		return false
+	}
+	return check("LISProtected", items, sorted, rest, chain{
+		ok:    func(a, b item) bool { return a.val <= b.val && !skipsProtected(a.idx, b.idx) },
+		first: func(a item) bool { return a.idx <= firstProtected },
+		last:  func(a item) bool { return a.idx >= lastProtected },
+	})
+}
+
+// CheckMinDiff checks lis.LISMinDiff, with d decoded as the parameter
+// of DecodeParam. Consecutive elements of the subsequence must differ
+// by at least d.
+func CheckMinDiff(data []byte) error {
+	d, lst := DecodeParam(data)
+	items := newItems(lst)
+	sorted, rest := lis.LISMinDiff(items, itemVal, d)
+	return check(fmt.Sprintf("LISMinDiff(d=%d)", d), items, sorted, rest, chain{
+		ok: func(a, b item) bool { return b.val-a.val >= d },
+	})
+}
+
+// CheckMaxDiff checks lis.LISMaxDiff, with g decoded as the parameter
+// of DecodeParam. Consecutive elements of the subsequence must be
+// non-decreasing and differ by at most g.
+func CheckMaxDiff(data []byte) error {
+	g, lst := DecodeParam(data)
+	items := newItems(lst)
+	sorted, rest := lis.LISMaxDiff(items, itemVal, g)
+	return check(fmt.Sprintf("LISMaxDiff(g=%d)", g), items, sorted, rest, chain{
+		ok: func(a, b item) bool { return a.val <= b.val && b.val-a.val <= g },
+	})
+}
+
+// CheckWindow checks lis.LISWindow, with w decoded as the parameter
+// of DecodeParam. Consecutive elements of the subsequence must be
+// non-decreasing and at most w positions apart.
+func CheckWindow(data []byte) error {
+	w, lst := DecodeParam(data)
+	items := newItems(lst)
+	sorted, rest := lis.LISWindow(items, compareItems, w)
+	return check(fmt.Sprintf("LISWindow(w=%d)", w), items, sorted, rest, chain{
+		ok: func(a, b item) bool { return a.val <= b.val && b.idx-a.idx <= w },
+	})
+}
+
+// CheckMSIS checks lis.MSIS, which must return a non-decreasing
+// subsequence with the greatest sum. Values are decoded as by
+// DecodeWeighted's weights, so that some are negative.
+func CheckMSIS(data []byte) error {
+	_, lst := DecodeWeighted(data)
+	sorted, rest := lis.MSIS(lst)
+
+	items := newItems(lst)
+	if err := checkPartition("MSIS", items, fromVals(sorted), fromVals(rest)); err != nil {
+		return err
+	}
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] < sorted[i-1] {
+			return fmt.Errorf("MSIS subsequence %v is not non-decreasing", sorted)
+		}
+	}
+	if len(lst) > 0 && len(sorted) == 0 {
+		return fmt.Errorf("MSIS returned an empty subsequence for a non-empty input")
+	}
+	got := 0
+	for _, v := range sorted {
+		got += v
+	}
+	if want := bestSum(lst); got != want {
+		return fmt.Errorf("MSIS subsequence %v has sum %d, want %d", sorted, got, want)
+	}
+	return nil
+}
+
+// An item is an input element, along with its index in the input, so
+// that checks can tell equal elements apart.
+type item struct {
+	val, idx int
+}
+
+func newItems(lst []int) []item {
+	ret := make([]item, len(lst))
+	for i, v := range lst {
+		ret[i] = item{v, i}
+	}
+	return ret
+}
+
+// fromVals is newItems, for the outputs of functions that return
+// plain values. The indices it assigns are meaningless.
+func fromVals(lst []int) []item {
+	ret := make([]item, len(lst))
+	for i, v := range lst {
+		ret[i] = item{v, -1}
+	}
+	return ret
+}
+
+func vals(items []item) []int {
+	ret := make([]int, len(items))
+	for i, it := range items {
+		ret[i] = it.val
+	}
+	return ret
+}
+
+func compareItems(a, b item) int { return cmp.Compare(a.val, b.val) }
+
+func itemVal(it item) int { return it.val }
+
+// A chain describes the subsequences that a mode may return.
+type chain struct {
+	// ok reports whether b may directly follow a in a subsequence.
+	ok func(a, b item) bool
+	// first and last report whether a subsequence may start or end
+	// with an element. If nil, any element may.
+	first, last func(item) bool
+}
+
+func (c chain) canStart(it item) bool { return c.first == nil || c.first(it) }
+func (c chain) canEnd(it item) bool   { return c.last == nil || c.last(it) }
+
+// check checks that sorted and rest partition items, that sorted is a
+// valid chain, and that no valid chain is longer.
+func check(name string, items, sorted, rest []item, c chain) error {
+	if err := checkPartition(name, items, sorted, rest); err != nil {
+		return err
+	}
+	for i := 1; i < len(sorted); i++ {
+		if !c.ok(sorted[i-1], sorted[i]) {
+			return fmt.Errorf("%s subsequence %v has %d followed by %d, which is not allowed", name, vals(sorted), sorted[i-1].val, sorted[i].val)
+		}
+	}
+	if len(sorted) > 0 && (!c.canStart(sorted[0]) || !c.canEnd(sorted[len(sorted)-1])) {
+		return fmt.Errorf("%s subsequence %v starts or ends at an element it may not", name, vals(sorted))
+	}
+	if got, want := len(sorted), longestChain(items, c); got != want {
+		return fmt.Errorf("%s subsequence %v has length %d, want %d", name, vals(sorted), got, want)
+	}
+	return nil
+}
+
+// checkPartition checks that sorted and rest are a partition of items
+// that preserves their relative order. If the outputs carry indices,
+// they must be the items' own.
+func checkPartition(name string, items, sorted, rest []item) error {
+	if got, want := len(sorted)+len(rest), len(items); got != want {
+		return fmt.Errorf("%s returned %d+%d elements for an input of %d", name, len(sorted), len(rest), want)
+	}
+	same := func(a, b item) bool { return a.val == b.val && (a.idx < 0 || a.idx == b.idx) }
+
+	// Match outputs to the input greedily, preferring sorted. With
+	// indices, matching is exact. Without, preferring sorted is safe:
+	// if both sorted and rest could take an input element, the two
+	// are equal, and either choice leaves the same remaining
+	// elements to match.
+	var s, r int
+	for _, it := range items {
+		switch {
+		case s < len(sorted) && same(sorted[s], it):
+			s++
+		case r < len(rest) && same(rest[r], it):
+			r++
+		default:
+			return fmt.Errorf("%s outputs %v and %v are not an in-order partition of input %v", name, vals(sorted), vals(rest), vals(items))
+		}
+	}
+	return nil
+}
+
+// longestChain returns the length of the longest valid chain in items,
+// in O(n²) time.
+func longestChain(items []item, c chain) int {
+	best := 0
+	lens := make([]int, len(items))
+	for i, it := range items {
+		if c.canStart(it) {
+			lens[i] = 1
+		}
+		for j := range i {
+			if lens[j] > 0 && lens[j]+1 > lens[i] && c.ok(items[j], it) {
+				lens[i] = lens[j] + 1
+			}
+		}
+		if lens[i] > 0 && c.canEnd(it) {
+			best = max(best, lens[i])
+		}
+	}
+	return best
+}
+
+// bestWeight returns the greatest total weight of a longest
+// non-decreasing subsequence of lst, in O(n²) time.
+func bestWeight(lst, weights []int) int {
+	var (
+		lens   = make([]int, len(lst))
+		totals = make([]int, len(lst))
+		best   struct{ len, total int }
+	)
+	for i, v := range lst {
+		lens[i], totals[i] = 1, weights[i]
+		for j := range i {
+			if lst[j] > v {
+				continue
+			}
+			l, t := lens[j]+1, totals[j]+weights[i]
+			if l > lens[i] || (l == lens[i] && t > totals[i]) {
+				lens[i], totals[i] = l, t
+			}
+		}
+		if lens[i] > best.len || (lens[i] == best.len && totals[i] > best.total) {
+			best.len, best.total = lens[i], totals[i]
+		}
+	}
+	return best.total
+}
+
+// bestSum returns the greatest sum of a non-empty non-decreasing
+// subsequence of lst, in O(n²) time.
+func bestSum(lst []int) int {
+	best := 0
+	sums := make([]int, len(lst))
+	for i, v := range lst {
+		sums[i] = v
+		for j := range i {
+			if lst[j] <= v {
+				sums[i] = max(sums[i], sums[j]+v)
+			}
+		}
+		if i == 0 || sums[i] > best {
+			best = sums[i]
+		}
+	}
+	return best
+}
+
+// protectedConflict reports whether two protected elements of lst are
+// out of order.
+func protectedConflict(lst []int, protected []bool) bool {
+	last := -1
+	for i, p := range protected {
+		if !p {
+			continue
+		}
+		if last >= 0 && lst[last] > lst[i] {
+			return true
+		}
+		last = i
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package fuzzshims
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestTargets(t *testing.T) {
+	t.Parallel()
+
+	const numIters = 200
+
+	names := make([]string, 0, len(Targets))
+	for name := range Targets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		check := Targets[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for _, seed := range Seeds {
+				if err := check(seed); err != nil {
+					t.Errorf("seed %v: %v", seed, err)
+				}
+			}
+			for range numIters {
+				data := make([]byte, rand.Intn(64))
+				for i := range data {
+					// Draw from a small alphabet, so that inputs have
+					// plenty of duplicates, and set the high bits at
+					// random, so that some elements are protected or
+					// negative.
+					data[i] = byte(rand.Intn(8)) | byte(rand.Intn(4))<<6
+				}
+				if err := check(data); err != nil {
+					t.Errorf("input %v: %v", data, err)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeTruncates(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 3*MaxLen)
+	if got := len(DecodeInts(data)); got != MaxLen {
+		t.Errorf("DecodeInts returned %d elements, want %d", got, MaxLen)
+	}
+	if got, _ := DecodeWeighted(data); len(got) != MaxLen {
+		t.Errorf("DecodeWeighted returned %d elements, want %d", len(got), MaxLen)
+	}
+	if got, _ := DecodeProtected(data); len(got) != MaxLen {
+		t.Errorf("DecodeProtected returned %d elements, want %d", len(got), MaxLen)
+	}
+	if _, got := DecodeParam(data); len(got) != MaxLen {
+		t.Errorf("DecodeParam returned %d elements, want %d", len(got), MaxLen)
+	}
+}
+
+func fuzz(f *testing.F, check func([]byte) error) {
+	for _, seed := range Seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := check(data); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func FuzzLIS(f *testing.F)       { fuzz(f, CheckLIS) }
+func FuzzStrict(f *testing.F)    { fuzz(f, CheckStrict) }
+func FuzzLDS(f *testing.F)       { fuzz(f, CheckLDS) }
+func FuzzLNIS(f *testing.F)      { fuzz(f, CheckLNIS) }
+func FuzzPriority(f *testing.F)  { fuzz(f, CheckPriority) }
+func FuzzProtected(f *testing.F) { fuzz(f, CheckProtected) }
+func FuzzMinDiff(f *testing.F)   { fuzz(f, CheckMinDiff) }
+func FuzzMaxDiff(f *testing.F)   { fuzz(f, CheckMaxDiff) }
+func FuzzWindow(f *testing.F)    { fuzz(f, CheckWindow) }
+func FuzzMSIS(f *testing.F)      { fuzz(f, CheckMSIS) }